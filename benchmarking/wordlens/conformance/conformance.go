@@ -0,0 +1,134 @@
+// Package conformance checks that wordlens implementations agree on what a
+// palindrome is, for any input including invalid UTF-8 and empty words.
+//
+// An implementation plugs in by wrapping its FindPalindromes in an
+// Implementation and passing it to Run from a test or to Fuzz from a fuzz
+// target.
+package conformance
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+)
+
+// Implementation counts the palindromes in words.
+type Implementation func(words []string) map[string]int
+
+// IsPalindrome is the reference definition: a word is a palindrome when its
+// runes read the same in both directions, decoding invalid UTF-8 one byte at
+// a time as utf8.RuneError like a range loop does. The empty word is a
+// palindrome.
+func IsPalindrome(word string) bool {
+	runes := []rune(word)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		if runes[i] != runes[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// Reference counts the palindromes in words using IsPalindrome.
+func Reference(words []string) map[string]int {
+	res := make(map[string]int)
+	for _, word := range words {
+		if IsPalindrome(word) {
+			res[word]++
+		}
+	}
+	return res
+}
+
+// alphabet mixes ASCII, multi-byte runes, a combining accent, invalid
+// bytes and the bytes of a four byte rune, so generated words exercise
+// every decoding path.
+var alphabet = []string{"a", "b", "e", "é", "́", "ß", "가", "🙂", "\xff", "\xe2\x82", "\xf0\x9f", "\x98\x82"}
+
+// Inputs returns named word lists: the fixed test words plus words generated
+// from a fixed seed, half of which are built to be palindromes.
+func Inputs() map[string][]string {
+	r := rand.New(rand.NewPCG(1, 2))
+	generated := make([]string, 0, 1000)
+	for i := 0; i < cap(generated); i++ {
+		parts := make([]string, r.IntN(6))
+		for j := range parts {
+			parts[j] = alphabet[r.IntN(len(alphabet))]
+		}
+		word := strings.Join(parts, "")
+		if i%2 == 0 {
+			// mirror the runes, which turns invalid bytes into U+FFFD
+			runes := []rune(word)
+			slices.Reverse(runes)
+			word += string(runes)
+		}
+		generated = append(generated, word)
+	}
+
+	return map[string][]string{
+		"empty":   {},
+		"ascii":   wordlens.TestWords(),
+		"unicode": wordlens.TestUnicodeWords(),
+		"edge": {
+			"", "", "a", "\xff", "\xffa\xff", "\xffa\xfe", "\xe2\x82", "\xe2\x82\xe2",
+			"é", "ée", "́é", "ab́ba", "🙂", "🙂🙃", "\xf0\x9f\x99\x82\x82\x99\x9f\xf0",
+		},
+		"generated": generated,
+	}
+}
+
+// Check fails t when impl does not count the same palindromes as Reference
+// in words.
+func Check(t testing.TB, name string, impl Implementation, words []string) {
+	t.Helper()
+	expected := Reference(words)
+	got := impl(words)
+	if maps.Equal(got, expected) {
+		return
+	}
+
+	var diffs []string
+	for word, n := range expected {
+		if got[word] != n {
+			diffs = append(diffs, fmt.Sprintf("%q: expected %d, got %d", word, n, got[word]))
+		}
+	}
+	for word, n := range got {
+		if _, ok := expected[word]; !ok {
+			diffs = append(diffs, fmt.Sprintf("%q: expected 0, got %d", word, n))
+		}
+	}
+	slices.Sort(diffs)
+	t.Errorf("%s: Expected the reference palindromes, got %d differences:\n%s", name, len(diffs), strings.Join(diffs, "\n"))
+}
+
+// Run checks every implementation against every input of Inputs.
+func Run(t *testing.T, impls map[string]Implementation) {
+	for input, words := range Inputs() {
+		t.Run(input, func(t *testing.T) {
+			for name, impl := range impls {
+				Check(t, name, impl, words)
+			}
+		})
+	}
+}
+
+// Fuzz checks every implementation against words split from fuzzed text
+// on single spaces, so empty words and arbitrary bytes are included. It is
+// seeded with Inputs.
+func Fuzz(f *testing.F, impls map[string]Implementation) {
+	for _, words := range Inputs() {
+		f.Add(strings.Join(words, " "))
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		words := strings.Split(text, " ")
+		for name, impl := range impls {
+			Check(t, name, impl, words)
+		}
+	})
+}
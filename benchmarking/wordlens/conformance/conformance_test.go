@@ -0,0 +1,45 @@
+package conformance_test
+
+import (
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens/conformance"
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens/optimized"
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens/unoptimized"
+	"github.com/idiomat/goo11ynyt/e1"
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+// implementations returns every wordlens in the repository, including each
+// registered e2 technique, so new techniques are checked automatically.
+func implementations() map[string]conformance.Implementation {
+	e1wl := e1.NewWordLens()
+	e2wl := e2.NewWordLens(e2.WithWorkers(3))
+
+	impls := map[string]conformance.Implementation{
+		"e1/sequential": func(words []string) map[string]int {
+			return e1wl.FindPalindromes(words, false)
+		},
+		"e1/concurrent": func(words []string) map[string]int {
+			return e1wl.FindPalindromes(words, true)
+		},
+		// optimized compares bytes by default, which is only right for ASCII
+		"optimized/runes": optimized.NewWordLens(optimized.WithMode(wordlens.ModeRunes)).FindPalindromes,
+		"unoptimized":     unoptimized.NewWordLens().FindPalindromes,
+	}
+	for _, tech := range append(e2.Techniques(), e2.TechniqueAuto) {
+		impls["e2/"+string(tech)] = func(words []string) map[string]int {
+			return e2wl.FindPalindromes(words, true, tech)
+		}
+	}
+	return impls
+}
+
+func TestConformance(t *testing.T) {
+	conformance.Run(t, implementations())
+}
+
+func FuzzConformance(f *testing.F) {
+	conformance.Fuzz(f, implementations())
+}
@@ -0,0 +1,250 @@
+package wordlens
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"strings"
+)
+
+// CorpusOptions describes a synthetic corpus for GenerateCorpus. Words are
+// drawn from a vocabulary of at most Vocabulary distinct words, of which
+// the palindromes make up a Density share of the corpus. Both kinds of
+// words are drawn with a Zipf distribution of exponent Zipf, so a few words
+// are very frequent as in real text, or uniformly if Zipf is 0. Lengths
+// weighs word lengths in runes, Lengths[i] being the relative frequency of
+// words of i+1 runes, and Unicode is the share of words written in a
+// non-Latin script or with accented letters. The same options and Seed
+// always generate the same corpus.
+type CorpusOptions struct {
+	Seed       int64
+	Words      int
+	Vocabulary int
+	Density    float64
+	Zipf       float64
+	Lengths    []float64
+	Unicode    float64
+}
+
+// EnglishLengths approximates how long English words are, from 1 to 15
+// letters.
+var EnglishLengths = []float64{3, 17, 21, 16, 11, 9, 8, 6, 4, 2, 1.5, 0.8, 0.4, 0.2, 0.1}
+
+var DefaultCorpusOptions = CorpusOptions{
+	Seed:       1,
+	Words:      100_000,
+	Vocabulary: 10_000,
+	Density:    0.05,
+	Zipf:       1.1,
+	Lengths:    EnglishLengths,
+	Unicode:    0,
+}
+
+func (o CorpusOptions) validate() error {
+	if o.Words < 0 {
+		return errors.New("words must not be negative")
+	}
+	if o.Vocabulary < 1 {
+		return errors.New("vocabulary must be positive")
+	}
+	if o.Vocabulary < 2 && o.Density > 0 && o.Density < 1 {
+		return errors.New("vocabulary must hold both palindromes and other words")
+	}
+	if o.Density < 0 || o.Density > 1 {
+		return errors.New("density must be between 0 and 1")
+	}
+	if o.Zipf != 0 && o.Zipf <= 1 {
+		return errors.New("zipf must be 0 or greater than 1")
+	}
+	if o.Unicode < 0 || o.Unicode > 1 {
+		return errors.New("unicode must be between 0 and 1")
+	}
+	total := 0.0
+	for _, w := range o.Lengths {
+		if w < 0 {
+			return errors.New("lengths must not be negative")
+		}
+		total += w
+	}
+	if total == 0 {
+		return errors.New("lengths must have a positive weight")
+	}
+	return nil
+}
+
+// alphabets are the letters words are made of, the first for ASCII words
+// and the others picked from for the Unicode share. All letters are single
+// precomposed runes, so a palindrome is one in every Mode but ModeBytes.
+var alphabets = [][]rune{
+	[]rune("abcdefghijklmnopqrstuvwxyz"),
+	[]rune("àáâäçèéêëìíîïñòóôöùúûüÿ"),
+	[]rune("αβγδεζηθικλμνξοπρστυφχψω"),
+	[]rune("абвгдежзийклмнопрстуфхцчшщыэюя"),
+	[]rune("日本語中文字的一是不了人我在有他这"),
+	[]rune("가나다라마바사아자차카타파하"),
+}
+
+// GenerateCorpus returns opts.Words words generated as described by opts.
+func GenerateCorpus(opts CorpusOptions) ([]string, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	r := rand.New(rand.NewPCG(uint64(opts.Seed), uint64(opts.Seed)))
+	g := generator{r: r, opts: opts}
+	g.cumulative = make([]float64, len(opts.Lengths))
+	total := 0.0
+	for i, w := range opts.Lengths {
+		total += w
+		g.cumulative[i] = total
+	}
+
+	palindromes := int(math.Round(float64(opts.Vocabulary) * opts.Density))
+	if opts.Density > 0 {
+		palindromes = max(palindromes, 1)
+	}
+	if opts.Density < 1 {
+		palindromes = min(palindromes, opts.Vocabulary-1)
+	}
+	pals := g.vocabulary(palindromes, true)
+	others := g.vocabulary(opts.Vocabulary-palindromes, false)
+
+	words := make([]string, opts.Words)
+	for i := 0; i < len(words); i++ {
+		if r.Float64() < opts.Density {
+			words[i] = pals.pick(r)
+		} else {
+			words[i] = others.pick(r)
+		}
+	}
+	return words, nil
+}
+
+type generator struct {
+	r          *rand.Rand
+	opts       CorpusOptions
+	cumulative []float64
+}
+
+// vocabulary is a list of words ranked by how often they are picked.
+type vocabulary struct {
+	words []string
+	zipf  *rand.Zipf
+}
+
+func (g *generator) vocabulary(n int, palindromes bool) vocabulary {
+	v := vocabulary{words: make([]string, n)}
+	for i := 0; i < n; i++ {
+		v.words[i] = g.word(palindromes)
+	}
+	if g.opts.Zipf > 0 && n > 1 {
+		v.zipf = rand.NewZipf(g.r, g.opts.Zipf, 1, uint64(n-1))
+	}
+	return v
+}
+
+func (v vocabulary) pick(r *rand.Rand) string {
+	if v.zipf != nil {
+		return v.words[v.zipf.Uint64()]
+	}
+	return v.words[r.IntN(len(v.words))]
+}
+
+// word returns a random word that is a palindrome or not, as asked. Words
+// that aren't palindromes have at least two runes.
+func (g *generator) word(palindrome bool) string {
+	length := 1 + g.length()
+	alphabet := alphabets[0]
+	if g.r.Float64() < g.opts.Unicode {
+		alphabet = alphabets[1+g.r.IntN(len(alphabets)-1)]
+	}
+
+	runes := make([]rune, length, max(length, 2))
+	if palindrome {
+		for i, j := 0, length-1; i <= j; i, j = i+1, j-1 {
+			runes[i] = alphabet[g.r.IntN(len(alphabet))]
+			runes[j] = runes[i]
+		}
+		return string(runes)
+	}
+
+	if length == 1 {
+		runes = runes[:2]
+	}
+	for i := range runes {
+		runes[i] = alphabet[g.r.IntN(len(alphabet))]
+	}
+	// make sure the ends differ, which rules out a palindrome
+	last := len(runes) - 1
+	for runes[last] == runes[0] {
+		runes[last] = alphabet[g.r.IntN(len(alphabet))]
+	}
+	return string(runes)
+}
+
+// length returns a word length in runes, less one, weighted by
+// opts.Lengths.
+func (g *generator) length() int {
+	x := g.r.Float64() * g.cumulative[len(g.cumulative)-1]
+	for i, c := range g.cumulative {
+		if x < c {
+			return i
+		}
+	}
+	return len(g.cumulative) - 1
+}
+
+// CorpusShape names options for GenerateCorpus that the wordlens
+// benchmarks scale over.
+type CorpusShape struct {
+	Name    string
+	Options CorpusOptions
+}
+
+// CorpusShapes returns the default corpus and variations of it that are
+// mostly non-ASCII, dense in palindromes or made of long words.
+func CorpusShapes() []CorpusShape {
+	unicode := DefaultCorpusOptions
+	unicode.Unicode = 0.8
+	dense := DefaultCorpusOptions
+	dense.Density = 0.5
+	long := DefaultCorpusOptions
+	long.Lengths = []float64{0, 0, 0, 0, 0, 0, 0, 1, 2, 3, 4, 5, 4, 3, 2, 1}
+
+	return []CorpusShape{
+		{Name: "default", Options: DefaultCorpusOptions},
+		{Name: "unicode", Options: unicode},
+		{Name: "dense", Options: dense},
+		{Name: "long", Options: long},
+	}
+}
+
+// CorpusSizes returns powers of ten from 1,000 up to maxWords, always
+// ending with maxWords itself.
+func CorpusSizes(maxWords int) []int {
+	var sizes []int
+	for n := 1000; n < maxWords; n *= 10 {
+		sizes = append(sizes, n)
+	}
+	return append(sizes, maxWords)
+}
+
+// CorpusText joins words into lines of about 80 bytes, for readers.
+func CorpusText(words []string) []byte {
+	var sb strings.Builder
+	line := 0
+	for i, w := range words {
+		if i > 0 {
+			if line+1+len(w) > 80 {
+				sb.WriteByte('\n')
+				line = 0
+			} else {
+				sb.WriteByte(' ')
+				line++
+			}
+		}
+		sb.WriteString(w)
+		line += len(w)
+	}
+	return []byte(sb.String())
+}
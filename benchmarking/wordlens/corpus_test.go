@@ -0,0 +1,155 @@
+package wordlens_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens/conformance"
+)
+
+func TestGenerateCorpus(t *testing.T) {
+	unicode := wordlens.DefaultCorpusOptions
+	unicode.Unicode = 0.5
+	dense := wordlens.DefaultCorpusOptions
+	dense.Density = 0.5
+	uniform := wordlens.DefaultCorpusOptions
+	uniform.Zipf = 0
+	long := wordlens.DefaultCorpusOptions
+	long.Lengths = []float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+
+	tests := map[string]struct {
+		opts wordlens.CorpusOptions
+	}{
+		"default": {opts: wordlens.DefaultCorpusOptions},
+		"unicode": {opts: unicode},
+		"dense":   {opts: dense},
+		"uniform": {opts: uniform},
+		"long":    {opts: long},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			words, err := wordlens.GenerateCorpus(tc.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(words) != tc.opts.Words {
+				t.Fatalf("Expected %d words, got %d", tc.opts.Words, len(words))
+			}
+
+			var palindromes, nonASCII int
+			counts := make(map[string]int)
+			for _, w := range words {
+				if conformance.IsPalindrome(w) {
+					palindromes++
+				}
+				if utf8.RuneCountInString(w) != len(w) {
+					nonASCII++
+				}
+				if n := utf8.RuneCountInString(w); n > len(tc.opts.Lengths) {
+					t.Fatalf("Expected at most %d runes, got %q", len(tc.opts.Lengths), w)
+				}
+				counts[w]++
+			}
+
+			within(t, "density", float64(palindromes)/float64(len(words)), tc.opts.Density)
+			within(t, "unicode", float64(nonASCII)/float64(len(words)), tc.opts.Unicode)
+			if len(counts) > tc.opts.Vocabulary {
+				t.Errorf("Expected at most %d distinct words, got %d", tc.opts.Vocabulary, len(counts))
+			}
+
+			// with a Zipf distribution the most frequent word stands out
+			top := 0
+			for _, n := range counts {
+				top = max(top, n)
+			}
+			if zipf := top > len(words)/100; zipf != (tc.opts.Zipf > 0) {
+				t.Errorf("Expected Zipf %t, got a top word with %d of %d words", tc.opts.Zipf > 0, top, len(words))
+			}
+		})
+	}
+}
+
+// within fails t unless got is close to expected, allowing for sampling
+// and, for the Unicode share, for which words happen to be frequent.
+func within(t *testing.T, name string, got, expected float64) {
+	t.Helper()
+	if math.Abs(got-expected) > 0.1 {
+		t.Errorf("Expected %s %.2f, got %.2f", name, expected, got)
+	}
+}
+
+func TestGenerateCorpusSeed(t *testing.T) {
+	opts := wordlens.DefaultCorpusOptions
+	opts.Words = 1000
+
+	a, _ := wordlens.GenerateCorpus(opts)
+	b, _ := wordlens.GenerateCorpus(opts)
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same seed to generate the same corpus")
+	}
+
+	opts.Seed++
+	c, _ := wordlens.GenerateCorpus(opts)
+	if reflect.DeepEqual(a, c) {
+		t.Errorf("Expected another seed to generate another corpus")
+	}
+}
+
+func TestGenerateCorpusInvalid(t *testing.T) {
+	tests := map[string]func(o *wordlens.CorpusOptions){
+		"negative words": func(o *wordlens.CorpusOptions) { o.Words = -1 },
+		"no vocabulary":  func(o *wordlens.CorpusOptions) { o.Vocabulary = 0 },
+		"one word":       func(o *wordlens.CorpusOptions) { o.Vocabulary = 1 },
+		"density":        func(o *wordlens.CorpusOptions) { o.Density = 1.5 },
+		"zipf":           func(o *wordlens.CorpusOptions) { o.Zipf = 0.5 },
+		"unicode":        func(o *wordlens.CorpusOptions) { o.Unicode = -1 },
+		"no lengths":     func(o *wordlens.CorpusOptions) { o.Lengths = nil },
+	}
+
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := wordlens.DefaultCorpusOptions
+			change(&opts)
+			if _, err := wordlens.GenerateCorpus(opts); err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestCorpusText(t *testing.T) {
+	words, _ := wordlens.GenerateCorpus(wordlens.DefaultCorpusOptions)
+	text := string(wordlens.CorpusText(words))
+	if got := strings.Fields(text); !reflect.DeepEqual(got, words) {
+		t.Errorf("Expected the text to hold the %d words, got %d", len(words), len(got))
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if len(line) > 80 {
+			t.Fatalf("Expected lines of at most 80 bytes, got %d", len(line))
+		}
+	}
+}
+
+func TestCorpusSizes(t *testing.T) {
+	tests := map[string]struct {
+		maxWords int
+		expected []int
+	}{
+		"small":        {maxWords: 500, expected: []int{500}},
+		"power of ten": {maxWords: 100_000, expected: []int{1000, 10_000, 100_000}},
+		"in between":   {maxWords: 50_000, expected: []int{1000, 10_000, 50_000}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := wordlens.CorpusSizes(tc.maxWords); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
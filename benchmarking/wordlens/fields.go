@@ -0,0 +1,11 @@
+package wordlens
+
+import "github.com/idiomat/goo11ynyt/text/tokenize"
+
+// Fields calls fn with every word of text, split around white space like
+// strings.Fields. Words are sub-slices of text rather than copies, so
+// nothing is allocated; fn must copy a word it wants to keep. It is
+// tokenize.None.Fields, see that package for other ways to split words.
+func Fields(text []byte, fn func(word []byte)) {
+	tokenize.None.Fields(text, fn)
+}
@@ -0,0 +1,33 @@
+package wordlens
+
+// Mode selects the unit in which a word is compared with its reverse by the
+// optimized and unoptimized packages, which benchmark the modes side by
+// side. e1 and e2 have no modes and always compare runes.
+type Mode int
+
+const (
+	// ModeBytes compares raw bytes, which is only correct for ASCII.
+	ModeBytes Mode = iota
+	// ModeRunes compares Unicode code points.
+	ModeRunes
+	// ModeGraphemes compares extended grapheme clusters, so combining marks
+	// and emoji sequences are kept together.
+	ModeGraphemes
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeBytes:
+		return "bytes"
+	case ModeRunes:
+		return "runes"
+	case ModeGraphemes:
+		return "graphemes"
+	}
+	return "unknown"
+}
+
+// Modes lists every Mode, in order of increasing cost.
+func Modes() []Mode {
+	return []Mode{ModeBytes, ModeRunes, ModeGraphemes}
+}
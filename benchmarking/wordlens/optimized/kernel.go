@@ -0,0 +1,42 @@
+package optimized
+
+import "math/bits"
+
+// kernelMinLen is the word length from which compareWords is used. Below
+// it, setting up the 8 byte loads costs more than comparing byte by byte;
+// see BenchmarkKernel.
+var kernelMinLen = 16
+
+// highBits has the top bit of every byte set, which is only the case for
+// bytes that are part of a multi-byte rune.
+const highBits = 0x8080808080808080
+
+// compareWords compares word 8 bytes at a time from both ends, reversing
+// the bytes loaded from the right so the two loads can be compared as
+// numbers. With asciiOnly it stops at the first load holding a non-ASCII
+// byte, since reversing the bytes of a multi-byte rune would break it. It
+// returns the middle of word that is still to be compared, or false on a
+// mismatch.
+func compareWords[T ~string | ~[]byte](word T, asciiOnly bool) (i, j int, ok bool) {
+	i, j = 0, len(word)
+	for j-i >= 16 {
+		a, b := load64(word, i), load64(word, j-8)
+		if asciiOnly && (a|b)&highBits != 0 {
+			break
+		}
+		if a != bits.ReverseBytes64(b) {
+			return i, j, false
+		}
+		i += 8
+		j -= 8
+	}
+	return i, j, true
+}
+
+// load64 reads 8 bytes of s starting at i as a little-endian number. The
+// compiler turns this into a single load.
+func load64[T ~string | ~[]byte](s T, i int) uint64 {
+	s = s[i : i+8]
+	return uint64(s[0]) | uint64(s[1])<<8 | uint64(s[2])<<16 | uint64(s[3])<<24 |
+		uint64(s[4])<<32 | uint64(s[5])<<40 | uint64(s[6])<<48 | uint64(s[7])<<56
+}
@@ -0,0 +1,147 @@
+package optimized
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCompareWords(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 10)
+	tests := map[string]struct {
+		word     string
+		expected bool
+	}{
+		"short": {
+			word:     "racecar",
+			expected: true,
+		},
+		"long": {
+			word:     long + reverse(long),
+			expected: true,
+		},
+		"long odd": {
+			word:     long + "x" + reverse(long),
+			expected: true,
+		},
+		"long near miss": {
+			word:     long + "xy" + reverse(long),
+			expected: false,
+		},
+		"long outer miss": {
+			word:     "z" + long + reverse(long),
+			expected: false,
+		},
+		"unicode": {
+			word:     long + "été" + reverse(long),
+			expected: true,
+		},
+		"unicode runes": {
+			word:     "日本語" + long + reverse(long) + "語本日",
+			expected: true,
+		},
+		"unicode miss": {
+			word:     "日本語" + long + reverse(long) + "日本語",
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, minLen := range []int{math.MaxInt, 16} {
+				kernelMinLen = minLen
+				if got := isPalindromeRunes(tc.word); got != tc.expected {
+					t.Errorf("Expected runes %t with kernelMinLen %d, got %t", tc.expected, minLen, got)
+				}
+				if got := isPalindromeUTF8([]byte(tc.word)); got != tc.expected {
+					t.Errorf("Expected utf8 %t with kernelMinLen %d, got %t", tc.expected, minLen, got)
+				}
+				if !strings.ContainsFunc(tc.word, func(r rune) bool { return r > 0x7f }) {
+					if got := isPalindromeBytes(tc.word); got != tc.expected {
+						t.Errorf("Expected bytes %t with kernelMinLen %d, got %t", tc.expected, minLen, got)
+					}
+				}
+			}
+			kernelMinLen = 16
+		})
+	}
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// kernelWords returns n palindromes with lengths drawn from length, so the
+// whole word is compared rather than stopping at the first byte.
+func kernelWords(n int, alphabet []rune, length func(r *rand.Rand) int) []string {
+	r := rand.New(rand.NewSource(1))
+	words := make([]string, n)
+	for i := 0; i < n; i++ {
+		half := make([]rune, length(r)/2)
+		for k := range half {
+			half[k] = alphabet[r.Intn(len(alphabet))]
+		}
+		words[i] = string(half) + reverse(string(half))
+	}
+	return words
+}
+
+func BenchmarkKernel(b *testing.B) {
+	ascii := []rune("abcdefghijklmnopqrstuvwxyz")
+	unicode := []rune("abcdeéèàüßжщ日本")
+	between := func(lo, hi int) func(r *rand.Rand) int {
+		return func(r *rand.Rand) int { return lo + r.Intn(hi-lo+1) }
+	}
+	dists := []struct {
+		name  string
+		words []string
+	}{
+		{"short", kernelWords(1024, ascii, between(2, 8))},
+		{"medium", kernelWords(1024, ascii, between(16, 64))},
+		{"long", kernelWords(1024, ascii, between(256, 1024))},
+		{"mixed", kernelWords(1024, ascii, func(r *rand.Rand) int {
+			return int(math.Exp(r.Float64() * math.Log(1024)))
+		})},
+		{"unicode", kernelWords(1024, unicode, between(256, 1024))},
+	}
+	kernels := []struct {
+		name   string
+		minLen int
+	}{
+		{"bytewise", math.MaxInt},
+		{"wordwise", 16},
+	}
+	modes := []struct {
+		name string
+		fn   func(string) bool
+	}{
+		{"bytes", isPalindromeBytes[string]},
+		{"runes", isPalindromeRunes},
+	}
+
+	defer func() { kernelMinLen = 16 }()
+	for _, d := range dists {
+		for _, m := range modes {
+			if d.name == "unicode" && m.name == "bytes" {
+				continue // reversed runes are not reversed bytes
+			}
+			for _, k := range kernels {
+				b.Run("dist="+d.name+"/mode="+m.name+"/kernel="+k.name, func(b *testing.B) {
+					kernelMinLen = k.minLen
+					for i := 0; i < b.N; i++ {
+						for _, w := range d.words {
+							if !m.fn(w) {
+								b.Fatalf("Expected %q to be a palindrome", w)
+							}
+						}
+					}
+				})
+			}
+		}
+	}
+}
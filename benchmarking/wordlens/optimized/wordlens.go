@@ -1,9 +1,56 @@
 package optimized
 
-type WordLens struct{}
+import (
+	"bytes"
+	"hash/maphash"
+	"strings"
+	"unicode/utf8"
 
-func NewWordLens() WordLens {
-	return WordLens{}
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/text/grapheme"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+type WordLens struct {
+	mode       wordlens.Mode
+	normalizer normalize.Normalizer
+	tokens     tokenize.Rules
+}
+
+type Option func(*WordLens)
+
+// WithNormalizer makes the WordLens compare words after normalizing them.
+// Results are still keyed by the original word.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(wl *WordLens) {
+		wl.normalizer = n
+	}
+}
+
+// WithMode selects whether words are compared by bytes (the default), runes
+// or grapheme clusters.
+func WithMode(m wordlens.Mode) Option {
+	return func(wl *WordLens) {
+		wl.mode = m
+	}
+}
+
+// WithTokenizer sets how FindPalindromesBytes splits text into words. It
+// defaults to tokenize.None, which splits around white space.
+func WithTokenizer(r tokenize.Rules) Option {
+	return func(wl *WordLens) {
+		wl.tokens = r
+	}
+}
+
+func NewWordLens(opts ...Option) WordLens {
+	wl := WordLens{}
+	for _, opt := range opts {
+		opt(&wl)
+	}
+	return wl
 }
 
 func (wl WordLens) FindPalindromes(words []string) map[string]int {
@@ -16,9 +63,144 @@ func (wl WordLens) FindPalindromes(words []string) map[string]int {
 	return palindromes
 }
 
+// FindPalindromesBytes is like FindPalindromes over the words of text as
+// split by the WordLens tokenizer, but checks every word in place and copies the
+// distinct palindromes into a single string at the end, so the number of
+// allocations doesn't grow with the number of words. A normalizer or
+// ModeGraphemes still need a string per word.
+func (wl WordLens) FindPalindromesBytes(text []byte) map[string]int {
+	seed := maphash.MakeSeed()
+	heads := make(map[uint64]int, 64)
+	entries := make([]bytesEntry, 0, 64)
+	size := 0
+
+	wl.tokens.Fields(text, func(word []byte) {
+		if !wl.isPalindromeBytes(word) {
+			return
+		}
+
+		h := maphash.Bytes(seed, word)
+		head, ok := heads[h]
+		if ok {
+			for i := head; i >= 0; i = entries[i].next {
+				if bytes.Equal(entries[i].word, word) {
+					entries[i].count++
+					return
+				}
+			}
+		} else {
+			head = -1
+		}
+		heads[h] = len(entries)
+		entries = append(entries, bytesEntry{word: word, count: 1, next: head})
+		size += len(word)
+	})
+
+	var sb strings.Builder
+	sb.Grow(size)
+	for _, e := range entries {
+		sb.Write(e.word)
+	}
+	keys := sb.String()
+
+	palindromes := make(map[string]int, len(entries))
+	for _, e := range entries {
+		palindromes[keys[:len(e.word)]] = e.count
+		keys = keys[len(e.word):]
+	}
+	return palindromes
+}
+
+// bytesEntry is a distinct palindrome found by FindPalindromesBytes. Words
+// with the same hash are chained through next.
+type bytesEntry struct {
+	word  []byte
+	count int
+	next  int
+}
+
+func (wl WordLens) isPalindromeBytes(word []byte) bool {
+	if wl.normalizer != nil || wl.mode == wordlens.ModeGraphemes {
+		return wl.isPalindrome(string(word))
+	}
+
+	if wl.mode == wordlens.ModeBytes {
+		return isPalindromeBytes(word)
+	}
+	return isPalindromeUTF8(word)
+}
+
 func (wl WordLens) isPalindrome(word string) bool {
-	for i := range word {
-		if word[i] != word[len(word)-1-i] {
+	if wl.normalizer != nil {
+		if word = wl.normalizer.Normalize(word); word == "" {
+			return false
+		}
+	}
+
+	switch wl.mode {
+	case wordlens.ModeBytes:
+		return isPalindromeBytes(word)
+	case wordlens.ModeGraphemes:
+		return isPalindromeGraphemes(word)
+	}
+	return isPalindromeRunes(word)
+}
+
+func isPalindromeBytes[T ~string | ~[]byte](word T) bool {
+	i, j := 0, len(word)
+	if len(word) >= kernelMinLen {
+		var ok bool
+		if i, j, ok = compareWords(word, false); !ok {
+			return false
+		}
+	}
+	for j--; i < j; i, j = i+1, j-1 {
+		if word[i] != word[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// isPalindromeRunes lets the kernel compare long words a machine word at a
+// time and leaves the middle it didn't settle to palindrome.Runes.
+func isPalindromeRunes(word string) bool {
+	i, j := 0, len(word)
+	if len(word) >= kernelMinLen {
+		var ok bool
+		if i, j, ok = compareWords(word, true); !ok {
+			return false
+		}
+	}
+	return palindrome.Runes(word[i:j])
+}
+
+// isPalindromeUTF8 is isPalindromeRunes for UTF-8 encoded bytes.
+func isPalindromeUTF8(word []byte) bool {
+	i, j := 0, len(word)
+	if len(word) >= kernelMinLen {
+		var ok bool
+		if i, j, ok = compareWords(word, true); !ok {
+			return false
+		}
+	}
+	for i < j {
+		first, n := utf8.DecodeRune(word[i:j])
+		last, m := utf8.DecodeLastRune(word[i:j])
+		if first != last {
+			return false
+		}
+		i += n
+		j -= m
+	}
+	return true
+}
+
+func isPalindromeGraphemes(word string) bool {
+	var buf [32]int
+	b := grapheme.Boundaries(buf[:0], word)
+	for i, j := 0, len(b)-2; i < j; i, j = i+1, j-1 {
+		if word[b[i]:b[i+1]] != word[b[j]:b[j+1]] {
 			return false
 		}
 	}
@@ -1,11 +1,18 @@
 package optimized_test
 
 import (
+	"bufio"
+	"bytes"
+	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
 	"github.com/idiomat/goo11ynyt/benchmarking/wordlens/optimized"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
 )
 
 func TestFindPalindromes(t *testing.T) {
@@ -43,6 +50,45 @@ func TestFindPalindromes(t *testing.T) {
 	}
 }
 
+func TestFindPalindromesNormalized(t *testing.T) {
+	tests := map[string]struct {
+		words    []string
+		expected int
+	}{
+		"mixed case": {
+			words:    []string{"Level", "Racecar", "Hello"},
+			expected: 2,
+		},
+		"punctuation": {
+			words:    []string{"Madam,", "(bob)", "tenet.", "print."},
+			expected: 3,
+		},
+		"diacritics": {
+			words:    []string{"été", "Ésope", "ÀnnA"},
+			expected: 2,
+		},
+		"phrases": {
+			words:    []string{"Step on no pets!", "A man, a plan, a canal: Panama", "not a palindrome"},
+			expected: 2,
+		},
+		"only punctuation": {
+			words:    []string{",", "--"},
+			expected: 0,
+		},
+	}
+
+	wl := optimized.NewWordLens(optimized.WithNormalizer(normalize.All))
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := wl.FindPalindromes(tc.words)
+			if len(res) != tc.expected {
+				t.Errorf("Expected %d palindromes, got %d", tc.expected, len(res))
+			}
+		})
+	}
+}
+
 func BenchmarkFindPalindromes(b *testing.B) {
 	b.StopTimer() // exclude preparations from the benchmark
 	wl := optimized.NewWordLens()
@@ -58,3 +104,148 @@ func BenchmarkFindPalindromes(b *testing.B) {
 		})
 	}
 }
+
+func TestFindPalindromesModes(t *testing.T) {
+	tests := map[string]struct {
+		mode     wordlens.Mode
+		expected int
+	}{
+		"bytes": {
+			mode:     wordlens.ModeBytes,
+			expected: 3,
+		},
+		"runes": {
+			mode:     wordlens.ModeRunes,
+			expected: 11,
+		},
+		"graphemes": {
+			mode:     wordlens.ModeGraphemes,
+			expected: 17,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wl := optimized.NewWordLens(optimized.WithMode(tc.mode))
+			res := wl.FindPalindromes(wordlens.TestUnicodeWords())
+			if len(res) != tc.expected {
+				t.Errorf("Expected %d palindromes, got %d: %v", tc.expected, len(res), res)
+			}
+		})
+	}
+}
+
+// BenchmarkPalindromeModes reports the cost of each comparison mode side by
+// side, on ASCII words and on words with multi-byte runes and clusters.
+func BenchmarkPalindromeModes(b *testing.B) {
+	inputs := []struct {
+		name  string
+		words []string
+	}{
+		{name: "ascii", words: wordlens.TestWords()},
+		{name: "unicode", words: wordlens.TestUnicodeWords()},
+	}
+
+	for _, in := range inputs {
+		for _, mode := range wordlens.Modes() {
+			wl := optimized.NewWordLens(optimized.WithMode(mode))
+			b.Run("input="+in.name+"/mode="+mode.String(), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					wl.FindPalindromes(in.words)
+				}
+			})
+		}
+	}
+}
+
+func TestFindPalindromesBytes(t *testing.T) {
+	book, err := os.ReadFile("../../../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	inputs := map[string][]byte{
+		"ascii":   wordlens.TestText(),
+		"unicode": []byte(strings.Join(wordlens.TestUnicodeWords(), "\u00a0")),
+		"book":    book,
+	}
+
+	for name, text := range inputs {
+		for _, mode := range wordlens.Modes() {
+			for _, rules := range []tokenize.Rules{tokenize.None, tokenize.All} {
+				t.Run(name+"/"+mode.String()+"/"+rules.String(), func(t *testing.T) {
+					wl := optimized.NewWordLens(optimized.WithMode(mode), optimized.WithTokenizer(rules))
+					expected := wl.FindPalindromes(rules.Split(string(text)))
+					if got := wl.FindPalindromesBytes(text); !reflect.DeepEqual(got, expected) {
+						t.Errorf("Expected %v, got %v", expected, got)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestFindPalindromesBytesAllocs(t *testing.T) {
+	book, err := os.ReadFile("../../../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	wl := optimized.NewWordLens()
+
+	// the words and the book have about as many distinct palindromes, so
+	// they should need about as many allocations despite their sizes
+	for name, text := range map[string][]byte{"words": wordlens.TestText(), "book": book} {
+		allocs := testing.AllocsPerRun(20, func() {
+			wl.FindPalindromesBytes(text)
+		})
+		if allocs > 10 {
+			t.Errorf("%s: Expected at most 10 allocations, got %v", name, allocs)
+		}
+	}
+}
+
+// BenchmarkTokenize compares scanning text into one string per word, as
+// the readers do, and splitting it with strings.Fields against checking
+// sub-slices of it in place.
+func BenchmarkTokenize(b *testing.B) {
+	book, err := os.ReadFile("../../../data/pg2680.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	inputs := []struct {
+		name string
+		text []byte
+	}{
+		{name: "words", text: wordlens.TestText()},
+		{name: "book", text: book},
+	}
+
+	wl := optimized.NewWordLens()
+	for _, in := range inputs {
+		b.Run("input="+in.name+"/path=scanner", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var words []string
+				scanner := bufio.NewScanner(bytes.NewReader(in.text))
+				scanner.Split(bufio.ScanWords)
+				for scanner.Scan() {
+					words = append(words, scanner.Text())
+				}
+				wl.FindPalindromes(words)
+			}
+		})
+		b.Run("input="+in.name+"/path=strings", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				wl.FindPalindromes(strings.Fields(string(in.text)))
+			}
+		})
+		b.Run("input="+in.name+"/path=bytes", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				wl.FindPalindromesBytes(in.text)
+			}
+		})
+	}
+}
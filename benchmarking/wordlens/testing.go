@@ -1,11 +1,24 @@
 package wordlens
 
 import (
-	"strings"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
 )
 
 func TestWords() []string {
-	return strings.Fields(words)
+	return tokenize.None.Split(words)
 }
 
+// TestText returns the text behind TestWords, for tokenizing it with Fields.
+func TestText() []byte {
+	return []byte(words)
+}
+
+// TestUnicodeWords returns words that mix precomposed and combining accents,
+// non-Latin scripts and emoji sequences.
+func TestUnicodeWords() []string {
+	return tokenize.None.Split(unicodeWords)
+}
+
+const unicodeWords string = "été e\u0301te\u0301 ÀnnA radar ōtō ȧbȧ kayak ละล 🇫🇷x🇫🇷 🇫🇷🇫🇷 👍🏽o👍🏽 👨\u200d👩\u200d👧 tenet noël 🙂🙃 αβα αβγ 가나가 ñoñ n\u0303on\u0303 müm café"
+
 const words string = "datetime tan bob close statement bib conditional package bin engineer ascii format ama nolemonnomelon amoreroma tenet classmethod with staticmethod docstring manager degrees wow pattern sys enumerate instance cwc expression hih pup from sorted atan float ada id mom positional radians module docstring true classmethod detartrated floor statement compile dir level developer block try rotator rotor while integer text complex apa complex sos continue rur for cuc sqrt loop generator abs round exec conjugate variable ascii mom input asin afa math random getter words tit aba reversed isinstance type from ata real zip world decimal print bin reduce civic range lol print"
@@ -1,9 +1,30 @@
 package unoptimized
 
-type WordLens struct{}
+import (
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/text/grapheme"
+)
 
-func NewWordLens() WordLens {
-	return WordLens{}
+type WordLens struct {
+	mode wordlens.Mode
+}
+
+type Option func(*WordLens)
+
+// WithMode selects whether words are compared by bytes, runes (the default)
+// or grapheme clusters.
+func WithMode(m wordlens.Mode) Option {
+	return func(wl *WordLens) {
+		wl.mode = m
+	}
+}
+
+func NewWordLens(opts ...Option) WordLens {
+	wl := WordLens{mode: wordlens.ModeRunes}
+	for _, opt := range opts {
+		opt(&wl)
+	}
+	return wl
 }
 
 func (wl WordLens) FindPalindromes(words []string) map[string]int {
@@ -18,6 +39,13 @@ func (wl WordLens) FindPalindromes(words []string) map[string]int {
 }
 
 func (wl WordLens) isPalindrome(word string) bool {
+	switch wl.mode {
+	case wordlens.ModeBytes:
+		return isPalindromeBytes(word)
+	case wordlens.ModeGraphemes:
+		return isPalindromeGraphemes(word)
+	}
+
 	runes := []rune(word)
 	n := len(runes)
 	for i := 0; i < n/2; i++ {
@@ -27,3 +55,25 @@ func (wl WordLens) isPalindrome(word string) bool {
 	}
 	return true
 }
+
+func isPalindromeBytes(word string) bool {
+	bs := []byte(word)
+	n := len(bs)
+	for i := 0; i < n/2; i++ {
+		if bs[i] != bs[n-1-i] {
+			return false
+		}
+	}
+	return true
+}
+
+func isPalindromeGraphemes(word string) bool {
+	clusters := grapheme.Clusters(word)
+	n := len(clusters)
+	for i := 0; i < n/2; i++ {
+		if clusters[i] != clusters[n-1-i] {
+			return false
+		}
+	}
+	return true
+}
@@ -58,3 +58,57 @@ func BenchmarkFindPalindromes(b *testing.B) {
 		})
 	}
 }
+
+func TestFindPalindromesModes(t *testing.T) {
+	tests := map[string]struct {
+		mode     wordlens.Mode
+		expected int
+	}{
+		"bytes": {
+			mode:     wordlens.ModeBytes,
+			expected: 3,
+		},
+		"runes": {
+			mode:     wordlens.ModeRunes,
+			expected: 11,
+		},
+		"graphemes": {
+			mode:     wordlens.ModeGraphemes,
+			expected: 17,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wl := unoptimized.NewWordLens(unoptimized.WithMode(tc.mode))
+			res := wl.FindPalindromes(wordlens.TestUnicodeWords())
+			if len(res) != tc.expected {
+				t.Errorf("Expected %d palindromes, got %d: %v", tc.expected, len(res), res)
+			}
+		})
+	}
+}
+
+// BenchmarkPalindromeModes reports the cost of each comparison mode side by
+// side, on ASCII words and on words with multi-byte runes and clusters.
+func BenchmarkPalindromeModes(b *testing.B) {
+	inputs := []struct {
+		name  string
+		words []string
+	}{
+		{name: "ascii", words: wordlens.TestWords()},
+		{name: "unicode", words: wordlens.TestUnicodeWords()},
+	}
+
+	for _, in := range inputs {
+		for _, mode := range wordlens.Modes() {
+			wl := unoptimized.NewWordLens(unoptimized.WithMode(mode))
+			b.Run("input="+in.name+"/mode="+mode.String(), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					wl.FindPalindromes(in.words)
+				}
+			})
+		}
+	}
+}
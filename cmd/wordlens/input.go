@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+// count finds the palindromes in every path, walking directories, or in
+// stdin when paths is empty or "-". Files are read one at a time, so a word
+// never spans two files, and a file that can't be read doesn't stop the
+// others from being counted. A file given by itself is split into ranges
+// that are read in parallel.
+func count(ctx context.Context, wl *e2.WordLens, paths []string, tech e2.Technique) (e2.Counts, error) {
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	counts := make(e2.Counts)
+	var errs []error
+	for _, path := range paths {
+		if path == "-" {
+			res, err := wl.FindPalindromesReader(ctx, os.Stdin, tech)
+			counts.Merge(res)
+			errs = append(errs, err)
+			continue
+		}
+
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			res, err := wl.FindPalindromesFile(ctx, path, tech)
+			counts.Merge(res)
+			errs = append(errs, err)
+			if errors.Is(err, e2.ErrIncomplete) {
+				break
+			}
+			continue
+		}
+
+		corpus, err := wl.FindPalindromesCorpus(ctx, path, tech, e2.CorpusOptions{})
+		if corpus != nil {
+			counts.Merge(corpus.Total)
+			for _, doc := range corpus.Failed() {
+				errs = append(errs, doc.Err)
+			}
+		}
+		errs = append(errs, err)
+		if errors.Is(err, e2.ErrIncomplete) {
+			break
+		}
+	}
+	return counts, errors.Join(errs...)
+}
+
+// countIndexed syncs every path into the index at indexPath, saving it
+// even if some files failed, and returns the counts of the whole index.
+func countIndexed(ctx context.Context, wl *e2.WordLens, indexPath string, paths []string, tech e2.Technique) (e2.Counts, error) {
+	if len(paths) == 0 || slices.Contains(paths, "-") {
+		return nil, errors.New("an index can only be built from files and directories")
+	}
+
+	ix, err := e2.OpenIndex(indexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, path := range paths {
+		stats, err := wl.Sync(ctx, ix, path, tech)
+		errs = append(errs, err)
+		errs = append(errs, stats.Errors...)
+		if errors.Is(err, e2.ErrIncomplete) {
+			break
+		}
+	}
+	errs = append(errs, ix.WriteFile(indexPath))
+	return ix.Total(), errors.Join(errs...)
+}
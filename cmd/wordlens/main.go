@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"slices"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+var (
+	technique  string
+	rules      string
+	tokens     string
+	top        int
+	format     string
+	numWorkers int
+	cpuprofile string
+	tracefile  string
+	indexPath  string
+	showReport bool
+)
+
+func init() {
+	flag.StringVar(&technique, "technique", string(e2.TechniqueAuto), "Technique to count with (auto or one of the registered techniques).")
+	flag.StringVar(&rules, "normalize", "none", "Comma separated normalization rules: case, punct, diacritics, space, all or none.")
+	flag.StringVar(&tokens, "tokenize", "none", "Comma separated tokenization rules: punct, hyphens, apostrophes, words, all or none.")
+	flag.IntVar(&top, "top", 10, "Number of palindromes to print, 0 for all.")
+	flag.StringVar(&format, "format", "table", "Output format: table, json or csv, or table, json or html with -report.")
+	flag.IntVar(&numWorkers, "workers", runtime.NumCPU(), "Number of workers. Defaults to system's number of CPUs.")
+	flag.StringVar(&cpuprofile, "cpuprofile", "", "write cpu profile to file")
+	flag.StringVar(&tracefile, "trace", "", "write execution trace to file")
+	flag.StringVar(&indexPath, "index", "", "Index file to update with the given files and print results from, so unchanged files aren't read again.")
+	flag.BoolVar(&showReport, "report", false, "Print palindrome statistics per section of a single file, detected from headings, listing the top palindromes of each.")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] [file|dir|-]...\n\nReads stdin when no file is given.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+}
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func run() error {
+	tech := e2.Technique(technique)
+	if tech != e2.TechniqueAuto && !slices.Contains(e2.Techniques(), tech) {
+		return fmt.Errorf("unknown technique %q, expected auto or one of %v", technique, e2.Techniques())
+	}
+	r, err := normalize.ParseRules(rules)
+	if err != nil {
+		return err
+	}
+	tr, err := tokenize.ParseRules(tokens)
+	if err != nil {
+		return err
+	}
+	write, ok := writers[format]
+	writeReport, reportOK := reportWriters[format]
+	switch {
+	case showReport && !reportOK:
+		return fmt.Errorf("unknown report format %q, expected table, json or html", format)
+	case showReport && indexPath != "":
+		return errors.New("a report can't be made from an index")
+	case !showReport && !ok:
+		return fmt.Errorf("unknown format %q, expected table, json or csv", format)
+	}
+
+	if cpuprofile != "" {
+		pf, err := os.Create(cpuprofile)
+		if err != nil {
+			return err
+		}
+		defer pf.Close()
+
+		if err := pprof.StartCPUProfile(pf); err != nil {
+			return err
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	if tracefile != "" {
+		tf, err := os.Create(tracefile)
+		if err != nil {
+			return err
+		}
+		defer tf.Close()
+
+		if err := trace.Start(tf); err != nil {
+			return err
+		}
+		defer trace.Stop()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := []e2.Option{e2.WithWorkers(numWorkers), e2.WithTokenizer(tr)}
+	if r != 0 {
+		opts = append(opts, e2.WithNormalizer(r))
+	}
+	wl := e2.NewWordLens(opts...)
+
+	// print what was counted even if some input failed or we were
+	// interrupted, then report the error
+	if showReport {
+		rep, err := report(ctx, &wl, flag.Args(), tech, top)
+		if rep == nil {
+			return err
+		}
+		return errors.Join(err, writeReport(os.Stdout, rep))
+	}
+
+	var counts e2.Counts
+	if indexPath != "" {
+		counts, err = countIndexed(ctx, &wl, indexPath, flag.Args(), tech)
+	} else {
+		counts, err = count(ctx, &wl, flag.Args(), tech)
+	}
+	return errors.Join(err, write(os.Stdout, counts.TopN(top)))
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestWriters(t *testing.T) {
+	entries := []e2.WordCount{{Word: "a", Count: 3}, {Word: "level,", Count: 1}}
+
+	tests := map[string]struct {
+		expected string
+	}{
+		"table": {
+			expected: "COUNT  WORD\n3      a\n1      level,\n",
+		},
+		"json": {
+			expected: "[\n  {\n    \"word\": \"a\",\n    \"count\": 3\n  },\n  {\n    \"word\": \"level,\",\n    \"count\": 1\n  }\n]\n",
+		},
+		"csv": {
+			expected: "word,count\na,3\n\"level,\",1\n",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writers[name](&buf, entries); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := buf.String(); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCount(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"one.txt":     "noon level noon",
+		"sub/two.txt": "did",
+		"sub/end.txt": "no", // would join with "on" if files were concatenated
+		"sub/on.txt":  "on",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		paths     []string
+		expected  e2.Counts
+		expectErr bool
+	}{
+		"file": {
+			paths:    []string{filepath.Join(dir, "one.txt")},
+			expected: e2.Counts{"noon": 2, "level": 1},
+		},
+		"directory": {
+			paths:    []string{dir},
+			expected: e2.Counts{"noon": 2, "level": 1, "did": 1},
+		},
+		"missing file": {
+			paths:     []string{filepath.Join(dir, "missing.txt"), filepath.Join(dir, "sub")},
+			expected:  e2.Counts{"did": 1},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wl := e2.NewWordLens()
+			got, err := count(context.Background(), &wl, tc.paths, e2.TechniqueSequential)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCountIndexed(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "one.txt"), []byte("noon level noon"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	indexPath := filepath.Join(t.TempDir(), "index.json")
+	wl := e2.NewWordLens()
+
+	for _, name := range []string{"build", "reuse"} {
+		t.Run(name, func(t *testing.T) {
+			got, err := countIndexed(context.Background(), &wl, indexPath, []string{dir}, e2.TechniqueSequential)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expected := e2.Counts{"noon": 2, "level": 1}
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Expected %v, got %v", expected, got)
+			}
+		})
+	}
+
+	if _, err := countIndexed(context.Background(), &wl, indexPath, nil, e2.TechniqueSequential); err == nil {
+		t.Error("Expected an error indexing stdin")
+	}
+}
+
+func TestReportWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "book.txt")
+	text := "noon\nTHE FIRST BOOK\nnoon level <b>\nhello a\n"
+	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wl := e2.NewWordLens()
+	rep, err := report(context.Background(), &wl, []string{path}, e2.TechniqueSequential, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := map[string]struct {
+		expected []string
+	}{
+		"table": {
+			expected: []string{
+				"(untitled)      1     1      1            1         1000.0    noon (1)\n",
+				"THE FIRST BOOK  2     5      3            3         600.0     a (1), level (1)\n",
+				"Total           1     6      4            3         666.7     noon (2), a (1)\n",
+				"THE FIRST BOOK  1  0  0  1  1\n",
+			},
+		},
+		"json": {
+			expected: []string{`"title": "THE FIRST BOOK"`, `"lengths": [`, `"word": "level"`},
+		},
+		"html": {
+			expected: []string{"<!DOCTYPE html>", "<td>THE FIRST BOOK</td>", "<td>(untitled)</td>", "a (1), level (1)", `style="width: 200px"`},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := reportWriters[name](&buf, rep); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			for _, s := range tc.expected {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("Expected %q in\n%s", s, buf.String())
+				}
+			}
+		})
+	}
+
+	if _, err := report(context.Background(), &wl, []string{path, path}, e2.TechniqueSequential, 2); err == nil {
+		t.Error("Expected an error reporting on two files")
+	}
+}
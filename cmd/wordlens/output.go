@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+var writers = map[string]func(w io.Writer, entries []e2.WordCount) error{
+	"table": writeTable,
+	"json":  writeJSON,
+	"csv":   writeCSV,
+}
+
+func writeTable(w io.Writer, entries []e2.WordCount) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "COUNT\tWORD")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%d\t%s\n", e.Count, e.Word)
+	}
+	return tw.Flush()
+}
+
+func writeJSON(w io.Writer, entries []e2.WordCount) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+func writeCSV(w io.Writer, entries []e2.WordCount) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"word", "count"}) //nolint:errcheck
+	for _, e := range entries {
+		cw.Write([]string{e.Word, strconv.Itoa(e.Count)}) //nolint:errcheck
+	}
+	cw.Flush()
+	return cw.Error()
+}
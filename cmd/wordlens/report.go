@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+// report makes a report of the single file at paths, or of stdin when paths
+// is empty or "-".
+func report(ctx context.Context, wl *e2.WordLens, paths []string, tech e2.Technique, top int) (*e2.Report, error) {
+	if len(paths) > 1 {
+		return nil, errors.New("a report can only be made of a single file")
+	}
+
+	rdr := io.Reader(os.Stdin)
+	if len(paths) == 1 && paths[0] != "-" {
+		f, err := os.Open(paths[0])
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		rdr = f
+	}
+
+	opts := e2.DefaultReportOptions
+	opts.Top = top
+	return wl.Report(ctx, rdr, tech, opts)
+}
+
+var reportWriters = map[string]func(w io.Writer, r *e2.Report) error{
+	"table": writeReportTable,
+	"json":  writeReportJSON,
+	"html":  writeReportHTML,
+}
+
+func writeReportTable(w io.Writer, r *e2.Report) error {
+	sections := slices.Concat(r.Sections, []e2.Section{r.Total})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SECTION\tLINE\tWORDS\tPALINDROMES\tDISTINCT\tPER 1000\tTOP")
+	for _, s := range sections {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t%.1f\t%s\n", title(s), s.Line, s.Words, s.Palindromes, s.Distinct, s.Density, topList(s.Top))
+	}
+	fmt.Fprintln(tw)
+
+	fmt.Fprintln(tw, "Palindromes by length in runes:")
+	fmt.Fprint(tw, "SECTION")
+	for i := range r.Total.Lengths {
+		fmt.Fprintf(tw, "\t%d", i+1)
+	}
+	fmt.Fprintln(tw)
+	for _, s := range sections {
+		fmt.Fprint(tw, title(s))
+		for i := range r.Total.Lengths {
+			fmt.Fprintf(tw, "\t%d", length(s, i))
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+func writeReportJSON(w io.Writer, r *e2.Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func writeReportHTML(w io.Writer, r *e2.Report) error {
+	return reportTemplate.Execute(w, r)
+}
+
+// title names a section in a report, which sections before the first
+// heading don't have.
+func title(s e2.Section) string {
+	if s.Title == "" {
+		return "(untitled)"
+	}
+	return s.Title
+}
+
+// topList formats palindromes as "word (count), ...".
+func topList(top []e2.WordCount) string {
+	var sb strings.Builder
+	for i, wc := range top {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(wc.Word + " (" + strconv.Itoa(wc.Count) + ")")
+	}
+	return sb.String()
+}
+
+// length returns the number of palindromes of i+1 runes in s.
+func length(s e2.Section, i int) int {
+	if i < len(s.Lengths) {
+		return s.Lengths[i]
+	}
+	return 0
+}
+
+// bar returns the width in pixels of the bar showing density, the densest
+// section of r getting the widest.
+func bar(density float64, r *e2.Report) int {
+	var most float64
+	for _, s := range r.Sections {
+		most = max(most, s.Density)
+	}
+	if most == 0 {
+		return 0
+	}
+	return int(200 * density / most)
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"title":   title,
+	"topList": topList,
+	"length":  length,
+	"inc":     func(i int) int { return i + 1 },
+	"bar":     bar,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Palindrome report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { padding: 0.3em 0.8em; border-bottom: 1px solid #ddd; text-align: right; }
+th:first-child, td:first-child, td.top { text-align: left; }
+tr.total { font-weight: bold; }
+.bar { display: inline-block; height: 0.8em; background: #4a7bb7; }
+</style>
+</head>
+<body>
+<h1>Palindrome report</h1>
+<p>{{.Total.Palindromes}} palindromes, {{.Total.Distinct}} distinct, in {{.Total.Words}} words and {{len .Sections}} sections.</p>
+
+<h2>Sections</h2>
+<table>
+<tr><th>Section</th><th>Line</th><th>Words</th><th>Palindromes</th><th>Distinct</th><th>Per 1,000 words</th><th></th><th>Most frequent</th></tr>
+{{- $total := .Total}}
+{{- range .Sections}}
+<tr><td>{{title .}}</td><td>{{.Line}}</td><td>{{.Words}}</td><td>{{.Palindromes}}</td><td>{{.Distinct}}</td><td>{{printf "%.1f" .Density}}</td><td><span class="bar" style="width: {{bar .Density $}}px"></span></td><td class="top">{{topList .Top}}</td></tr>
+{{- end}}
+{{- with .Total}}
+<tr class="total"><td>{{.Title}}</td><td>{{.Line}}</td><td>{{.Words}}</td><td>{{.Palindromes}}</td><td>{{.Distinct}}</td><td>{{printf "%.1f" .Density}}</td><td></td><td class="top">{{topList .Top}}</td></tr>
+{{- end}}
+</table>
+
+<h2>Palindromes by length in runes</h2>
+<table>
+<tr><th>Section</th>{{range $i, $_ := .Total.Lengths}}<th>{{inc $i}}</th>{{end}}</tr>
+{{- range .Sections}}
+{{- $s := .}}
+<tr><td>{{title .}}</td>{{range $i, $_ := $total.Lengths}}<td>{{length $s $i}}</td>{{end}}</tr>
+{{- end}}
+<tr class="total"><td>{{.Total.Title}}</td>{{range .Total.Lengths}}<td>{{.}}</td>{{end}}</tr>
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+const DefaultMaxBytes = 10 << 20
+const DefaultTimeout = 30 * time.Second
+
+// Handler analyses the text in a request body, or in every file of a
+// multipart/form-data upload, with the technique, normalization rules,
+// tokenization rules and top-N given as the "technique", "normalize",
+// "tokenize" and "top" query parameters.
+type Handler struct {
+	maxBytes int64
+	timeout  time.Duration
+	opts     []e2.Option
+}
+
+func (h *Handler) validate() error {
+	if h.maxBytes <= 0 {
+		return errors.New("maxBytes must be positive")
+	}
+	if h.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	return nil
+}
+
+// NewHandler creates a Handler accepting bodies of up to maxBytes and
+// analysing each for at most timeout, with opts applied to every WordLens.
+func NewHandler(maxBytes int64, timeout time.Duration, opts ...e2.Option) (*Handler, error) {
+	h := &Handler{maxBytes: maxBytes, timeout: timeout, opts: opts}
+	return h, h.validate()
+}
+
+type Response struct {
+	Technique   e2.Technique   `json:"technique"`
+	Normalize   string         `json:"normalize"`
+	Tokenize    string         `json:"tokenize"`
+	Files       int            `json:"files"`
+	Total       int            `json:"total"`
+	Palindromes []e2.WordCount `json:"palindromes"`
+	Incomplete  bool           `json:"incomplete,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, errors.New("only POST is supported"))
+		return
+	}
+
+	q := r.URL.Query()
+	tech := e2.Technique(cmp.Or(q.Get("technique"), string(e2.TechniqueAuto)))
+	if tech != e2.TechniqueAuto && !slices.Contains(e2.Techniques(), tech) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("unknown technique %q", tech))
+		return
+	}
+	rules, err := normalize.ParseRules(q.Get("normalize"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	tokens, err := tokenize.ParseRules(q.Get("tokenize"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	top := 0
+	if s := q.Get("top"); s != "" {
+		if top, err = strconv.Atoi(s); err != nil || top < 0 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid top %q", s))
+			return
+		}
+	}
+
+	opts := h.opts
+	if rules != 0 {
+		opts = append(slices.Clip(opts), e2.WithNormalizer(rules))
+	}
+	if tokens != tokenize.None {
+		opts = append(slices.Clip(opts), e2.WithTokenizer(tokens))
+	}
+	wl := e2.NewWordLens(opts...)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	body := &bodyReader{ReadCloser: http.MaxBytesReader(w, r.Body, h.maxBytes)}
+	r.Body = body
+
+	counts := make(e2.Counts)
+	add := func(rdr io.Reader) error {
+		res, err := wl.FindPalindromesReader(ctx, rdr, tech)
+		counts.Merge(res)
+		return err
+	}
+
+	files := 0
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		files, err = eachFile(r, add)
+	} else {
+		files, err = 1, add(r.Body)
+	}
+
+	res := Response{
+		Technique:   tech,
+		Normalize:   rules.String(),
+		Tokenize:    tokens.String(),
+		Files:       files,
+		Total:       len(counts),
+		Palindromes: counts.TopN(top),
+	}
+
+	status := http.StatusOK
+	var tooLarge *http.MaxBytesError
+	switch {
+	case err == nil:
+	case errors.As(body.err, &tooLarge):
+		// the multipart reader doesn't wrap the errors it gets from the body
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, context.DeadlineExceeded):
+		// partial results are still worth returning
+		status = http.StatusGatewayTimeout
+		res.Incomplete = true
+	case errors.Is(err, e2.ErrIncomplete):
+		// the client went away, nobody is left to read the response
+		return
+	default:
+		status = http.StatusBadRequest
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	writeJSON(w, status, res)
+}
+
+// bodyReader remembers the first error reading the request body.
+type bodyReader struct {
+	io.ReadCloser
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	return n, err
+}
+
+// eachFile calls fn with every file part of a multipart request and returns
+// how many there were.
+func eachFile(r *http.Request, fn func(io.Reader) error) (int, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return 0, err
+	}
+
+	files := 0
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return files, err
+		}
+		if part.FileName() == "" {
+			continue
+		}
+
+		files++
+		err = fn(part)
+		part.Close()
+		if err != nil {
+			return files, err
+		}
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v) //nolint:errcheck
+}
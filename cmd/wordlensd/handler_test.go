@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func init() {
+	// blocks until the request's analysis times out
+	e2.Register("stuck", e2.StrategyFunc(func(ctx context.Context, in e2.Input) (map[string]int, error) {
+		<-ctx.Done()
+		return map[string]int{}, ctx.Err()
+	}))
+}
+
+func TestNewHandler(t *testing.T) {
+	tests := map[string]struct {
+		maxBytes int64
+		timeout  time.Duration
+		wantErr  bool
+	}{
+		"valid configuration": {
+			maxBytes: 1024,
+			timeout:  time.Second,
+		},
+		"no size limit": {
+			maxBytes: 0,
+			timeout:  time.Second,
+			wantErr:  true,
+		},
+		"no timeout": {
+			maxBytes: 1024,
+			timeout:  0,
+			wantErr:  true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewHandler(tt.maxBytes, tt.timeout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHandler(%d, %v) error = %v, wantErr %v", tt.maxBytes, tt.timeout, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func multipartBody(t *testing.T, files map[string]string) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for name, content := range files {
+		fw, err := mw.CreateFormFile("file", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content)) //nolint:errcheck
+	}
+	mw.WriteField("comment", "not a file, kayak") //nolint:errcheck
+	mw.Close()
+	return &buf, mw.FormDataContentType()
+}
+
+func TestHandler(t *testing.T) {
+	upload, contentType := multipartBody(t, map[string]string{
+		"one.txt": "noon level",
+		"two.txt": "noon did",
+	})
+	large, largeType := multipartBody(t, map[string]string{
+		"large.txt": strings.Repeat("noon ", 1000),
+	})
+
+	tests := map[string]struct {
+		method      string
+		target      string
+		body        string
+		contentType string
+		status      int
+		expected    []e2.WordCount
+		files       int
+	}{
+		"text body": {
+			method:   http.MethodPost,
+			target:   "/palindromes?technique=workers",
+			body:     "noon level noon hello",
+			status:   http.StatusOK,
+			expected: []e2.WordCount{{Word: "noon", Count: 2}, {Word: "level", Count: 1}},
+			files:    1,
+		},
+		"normalized top 1": {
+			method:   http.MethodPost,
+			target:   "/palindromes?normalize=case,punct&top=1",
+			body:     "Noon, noon level",
+			status:   http.StatusOK,
+			expected: []e2.WordCount{{Word: "Noon,", Count: 1}},
+			files:    1,
+		},
+		"tokenized": {
+			method:   http.MethodPost,
+			target:   "/palindromes?tokenize=all",
+			body:     "noon, (noon) level.",
+			status:   http.StatusOK,
+			expected: []e2.WordCount{{Word: "noon", Count: 2}, {Word: "level", Count: 1}},
+			files:    1,
+		},
+		"uploaded files": {
+			method:      http.MethodPost,
+			target:      "/palindromes",
+			body:        upload.String(),
+			contentType: contentType,
+			status:      http.StatusOK,
+			expected:    []e2.WordCount{{Word: "noon", Count: 2}, {Word: "did", Count: 1}, {Word: "level", Count: 1}},
+			files:       2,
+		},
+		"too large": {
+			method: http.MethodPost,
+			target: "/palindromes",
+			body:   strings.Repeat("noon ", 1000),
+			status: http.StatusRequestEntityTooLarge,
+		},
+		"too large upload": {
+			method:      http.MethodPost,
+			target:      "/palindromes",
+			body:        large.String(),
+			contentType: largeType,
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		"timeout": {
+			method:   http.MethodPost,
+			target:   "/palindromes?technique=stuck",
+			body:     "noon",
+			status:   http.StatusGatewayTimeout,
+			expected: []e2.WordCount{},
+			files:    1,
+		},
+		"unknown technique": {
+			method: http.MethodPost,
+			target: "/palindromes?technique=magic",
+			status: http.StatusBadRequest,
+		},
+		"unknown rule": {
+			method: http.MethodPost,
+			target: "/palindromes?normalize=accents",
+			status: http.StatusBadRequest,
+		},
+		"unknown tokenize rule": {
+			method: http.MethodPost,
+			target: "/palindromes?tokenize=sentences",
+			status: http.StatusBadRequest,
+		},
+		"invalid top": {
+			method: http.MethodPost,
+			target: "/palindromes?top=-1",
+			status: http.StatusBadRequest,
+		},
+		"wrong method": {
+			method: http.MethodGet,
+			target: "/palindromes",
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+
+	h, err := NewHandler(1024, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tc.status {
+				t.Fatalf("Expected status %d, got %d: %s", tc.status, rec.Code, rec.Body)
+			}
+			if tc.expected == nil {
+				return
+			}
+
+			var res Response
+			if err := json.NewDecoder(rec.Body).Decode(&res); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(res.Palindromes, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, res.Palindromes)
+			}
+			if res.Files != tc.files {
+				t.Errorf("Expected %d files, got %d", tc.files, res.Files)
+			}
+			if res.Incomplete != (tc.status == http.StatusGatewayTimeout) {
+				t.Errorf("Expected incomplete to be %v, got %v", !res.Incomplete, res.Incomplete)
+			}
+		})
+	}
+}
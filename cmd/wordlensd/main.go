@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	_ "net/http/pprof"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var port = flag.String("port", "8090", "Port to listen on")
+var maxBytes = flag.Int64("max-bytes", DefaultMaxBytes, "Largest request body accepted, in bytes")
+var timeout = flag.Duration("timeout", DefaultTimeout, "How long a single analysis may take")
+var workers = flag.Int("workers", 0, "Number of workers per request. Defaults to system's number of CPUs.")
+
+func main() {
+	flag.Parse()
+
+	m, err := e2.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatalf("failed to register wordlens metrics: %v", err)
+	}
+
+	h, err := NewHandler(*maxBytes, *timeout, e2.WithMetrics(m), e2.WithWorkers(*workers))
+	if err != nil {
+		log.Fatalf("failed to create handler: %v", err)
+	}
+
+	// pprof registers itself on http.DefaultServeMux under /debug/pprof/
+	http.Handle("/metrics", promhttp.Handler())
+	http.Handle("/palindromes", h)
+
+	srv := &http.Server{
+		Addr:              ":" + *port,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       *timeout,
+		WriteTimeout:      2 * *timeout,
+	}
+
+	log.Printf("listening on :%s\n", *port)
+	if err := srv.ListenAndServe(); err != nil {
+		log.Fatalf("failed to listen and serve: %v", err)
+	}
+}
@@ -1,27 +1,80 @@
 package e1
 
-import "sync"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+)
 
 type WordLens struct {
 	mu sync.Mutex
+	config
+}
+
+type config struct {
+	normalizer normalize.Normalizer
+}
+
+type Option func(*config)
+
+// WithNormalizer makes the WordLens compare words after normalizing them,
+// e.g. with normalize.All to ignore case, punctuation, diacritics and
+// whitespace. Results are still keyed by the original word.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(c *config) {
+		c.normalizer = n
+	}
 }
 
-func NewWordLens() WordLens {
+func NewWordLens(opts ...Option) WordLens {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
 	return WordLens{
-		mu: sync.Mutex{},
+		mu:     sync.Mutex{},
+		config: c,
 	}
 }
 
+// ErrIncomplete is returned, wrapping the context's error, when a search was
+// stopped before every word was checked.
+var ErrIncomplete = errors.New("e1: incomplete results")
+
 func (wl *WordLens) FindPalindromes(words []string, concurrently bool) map[string]int {
+	palindromes, _ := wl.FindPalindromesContext(context.Background(), words, concurrently)
+	return palindromes
+}
+
+// FindPalindromesContext is like FindPalindromes but stops once ctx is done.
+// It waits for the goroutines it already started and returns the counts
+// gathered so far with an error matching both ErrIncomplete and ctx.Err().
+func (wl *WordLens) FindPalindromesContext(ctx context.Context, words []string, concurrently bool) (map[string]int, error) {
 	palindromes := make(map[string]int)
 
+	var err error
 	if concurrently {
 		wg := sync.WaitGroup{}
-		wg.Add(len(words))
+		var skipped atomic.Bool
 
 		for _, word := range words {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+
+			wg.Add(1)
 			go func(word string) {
 				defer wg.Done()
+				// goroutines may only get to run once ctx is already done
+				if ctx.Err() != nil {
+					skipped.Store(true)
+					return
+				}
 				if wl.isPalindrome(word) {
 					wl.mu.Lock()
 					palindromes[word]++
@@ -30,22 +83,31 @@ func (wl *WordLens) FindPalindromes(words []string, concurrently bool) map[strin
 			}(word)
 		}
 		wg.Wait()
+		if err == nil && skipped.Load() {
+			err = ctx.Err()
+		}
 	} else {
 		for _, word := range words {
+			if err = ctx.Err(); err != nil {
+				break
+			}
 			if wl.isPalindrome(word) {
 				palindromes[word]++
 			}
 		}
 	}
 
-	return palindromes
+	if err != nil {
+		return palindromes, fmt.Errorf("%w: %w", ErrIncomplete, err)
+	}
+	return palindromes, nil
 }
 
 func (wl *WordLens) isPalindrome(word string) bool {
-	for i := range word {
-		if word[i] != word[len(word)-1-i] {
+	if wl.normalizer != nil {
+		if word = wl.normalizer.Normalize(word); word == "" {
 			return false
 		}
 	}
-	return true
+	return palindrome.Runes(word)
 }
@@ -1,15 +1,20 @@
 package e1_test
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"strconv"
+	"sync/atomic"
 	"testing"
 
 	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
 	"github.com/idiomat/goo11ynyt/e1"
+	"github.com/idiomat/goo11ynyt/text/normalize"
 )
 
 var concurrent = flag.Bool("concurrent", false, "run the benchmark with concurrent palindromes search")
+var maxWords = flag.Int("words", 1_000_000, "run BenchmarkFindPalindromesCorpus with generated corpora of up to this many words")
 
 func TestFindPalindromes(t *testing.T) {
 	tests := map[string]struct {
@@ -46,6 +51,119 @@ func TestFindPalindromes(t *testing.T) {
 	}
 }
 
+func TestFindPalindromesNormalized(t *testing.T) {
+	tests := map[string]struct {
+		words    []string
+		expected int
+	}{
+		"mixed case": {
+			words:    []string{"Level", "Racecar", "Hello"},
+			expected: 2,
+		},
+		"punctuation": {
+			words:    []string{"Madam,", "(bob)", "tenet.", "print."},
+			expected: 3,
+		},
+		"diacritics": {
+			words:    []string{"été", "Ésope", "ÀnnA"},
+			expected: 2,
+		},
+		"phrases": {
+			words:    []string{"Step on no pets!", "A man, a plan, a canal: Panama", "not a palindrome"},
+			expected: 2,
+		},
+		"only punctuation": {
+			words:    []string{",", "--"},
+			expected: 0,
+		},
+	}
+
+	wl := e1.NewWordLens(e1.WithNormalizer(normalize.All))
+
+	for name, tc := range tests {
+		for _, concurrently := range []bool{false, true} {
+			t.Run(name+"/"+strconv.FormatBool(concurrently), func(t *testing.T) {
+				res := wl.FindPalindromes(tc.words, concurrently)
+				if len(res) != tc.expected {
+					t.Errorf("Expected %d palindromes, got %d", tc.expected, len(res))
+				}
+			})
+		}
+	}
+}
+
+func TestFindPalindromesContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := map[string]struct {
+		ctx      context.Context
+		expected int
+		wantErr  bool
+	}{
+		"background": {
+			ctx:      context.Background(),
+			expected: 27,
+			wantErr:  false,
+		},
+		"canceled": {
+			ctx:      canceled,
+			expected: 0,
+			wantErr:  true,
+		},
+	}
+
+	wl := e1.NewWordLens()
+
+	for name, tc := range tests {
+		for _, concurrently := range []bool{false, true} {
+			t.Run(name+"/"+strconv.FormatBool(concurrently), func(t *testing.T) {
+				res, err := wl.FindPalindromesContext(tc.ctx, wordlens.TestWords(), concurrently)
+				if (err != nil) != tc.wantErr {
+					t.Fatalf("Expected error %v, got %v", tc.wantErr, err)
+				}
+				if tc.wantErr && (!errors.Is(err, e1.ErrIncomplete) || !errors.Is(err, context.Canceled)) {
+					t.Errorf("Expected incomplete and canceled error, got %v", err)
+				}
+				if len(res) != tc.expected {
+					t.Errorf("Expected %d palindromes, got %d", tc.expected, len(res))
+				}
+			})
+		}
+	}
+}
+
+func TestFindPalindromesCanceledMidway(t *testing.T) {
+	for _, concurrently := range []bool{false, true} {
+		t.Run(strconv.FormatBool(concurrently), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// cancel once some words were checked, while goroutines for
+			// later words may still be waiting to run
+			var seen atomic.Int64
+			wl := e1.NewWordLens(e1.WithNormalizer(normalize.Func(func(s string) string {
+				if seen.Add(1) == 1000 {
+					cancel()
+				}
+				return s
+			})))
+
+			var words []string
+			for i := 0; i < 100; i++ {
+				words = append(words, wordlens.TestWords()...)
+			}
+			_, err := wl.FindPalindromesContext(ctx, words, concurrently)
+			if !errors.Is(err, e1.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected incomplete and canceled error, got %v", err)
+			}
+			if n := seen.Load(); n >= int64(len(words)) {
+				t.Errorf("Expected fewer than %d words to be checked, got %d", len(words), n)
+			}
+		})
+	}
+}
+
 func BenchmarkFindPalindromes(b *testing.B) {
 	b.StopTimer() // exclude preparations from the benchmark
 	flag.Parse()
@@ -62,3 +180,32 @@ func BenchmarkFindPalindromes(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkFindPalindromesCorpus measures how FindPalindromes scales with
+// the number of words, on generated corpora of several shapes.
+func BenchmarkFindPalindromesCorpus(b *testing.B) {
+	b.StopTimer() // exclude preparations from the benchmark
+	flag.Parse()
+	wl := e1.NewWordLens()
+	sizes := wordlens.CorpusSizes(*maxWords)
+
+	for _, shape := range wordlens.CorpusShapes() {
+		opts := shape.Options
+		opts.Words = sizes[len(sizes)-1]
+		allWords, err := wordlens.GenerateCorpus(opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.StartTimer() // run the benchmark
+		for _, n := range sizes {
+			words := allWords[:n]
+			b.Run("shape="+shape.Name+"/words="+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					wl.FindPalindromes(words, *concurrent)
+				}
+			})
+		}
+		b.StopTimer()
+	}
+}
@@ -0,0 +1,118 @@
+package e2
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"slices"
+)
+
+// ApproxOptions bounds the memory used by FindPalindromesApprox. K is the
+// number of words tracked exactly enough to be ranked, and Epsilon and Delta
+// the error bounds of the count-min sketch estimating every other word.
+type ApproxOptions struct {
+	K       int
+	Epsilon float64
+	Delta   float64
+}
+
+var DefaultApproxOptions = ApproxOptions{
+	K:       100,
+	Epsilon: 0.0001,
+	Delta:   0.01,
+}
+
+// HeavyHitter is a frequent word with its estimated count, which may
+// overcount it by at most Error.
+type HeavyHitter struct {
+	Word  string `json:"word"`
+	Count uint64 `json:"count"`
+	Error uint64 `json:"error"`
+}
+
+// HeavyHitters combines a SpaceSaving summary, which ranks the most
+// frequent words, with a CountMinSketch, which tightens their counts and
+// estimates the rest. Its memory use is fixed by its ApproxOptions.
+type HeavyHitters struct {
+	sketch *CountMinSketch
+	top    *SpaceSaving
+}
+
+func NewHeavyHitters(opts ApproxOptions) (*HeavyHitters, error) {
+	sketch, err := NewCountMinSketch(opts.Epsilon, opts.Delta)
+	if err != nil {
+		return nil, err
+	}
+	top, err := NewSpaceSaving(opts.K)
+	if err != nil {
+		return nil, err
+	}
+	return &HeavyHitters{sketch: sketch, top: top}, nil
+}
+
+func (hh *HeavyHitters) Add(word string, n uint64) {
+	hh.sketch.Add(word, n)
+	hh.top.Add(word, n)
+}
+
+// Merge adds the counts of o, which must have been created with the same
+// ApproxOptions, to hh.
+func (hh *HeavyHitters) Merge(o *HeavyHitters) error {
+	if err := hh.sketch.Merge(o.sketch); err != nil {
+		return err
+	}
+	hh.top.Merge(o.top)
+	return nil
+}
+
+// Estimate returns an upper bound on how often word was added.
+func (hh *HeavyHitters) Estimate(word string) uint64 {
+	count, _ := hh.top.Estimate(word)
+	return min(count, hh.sketch.Estimate(word))
+}
+
+// Total is the sum of every count added.
+func (hh *HeavyHitters) Total() uint64 {
+	return hh.sketch.Total()
+}
+
+// Top returns at most n of the most frequent words, or every tracked word
+// when n is 0. Both summaries overcount, so each count is the lower of
+// their estimates and Error is reduced to match.
+func (hh *HeavyHitters) Top(n int) []HeavyHitter {
+	top := hh.top.Top()
+	for i, h := range top {
+		if est := hh.sketch.Estimate(h.Word); est < h.Count {
+			top[i].Error -= min(h.Error, h.Count-est)
+			top[i].Count = est
+		}
+	}
+	slices.SortFunc(top, func(a, b HeavyHitter) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+
+	if n > 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
+
+// FindPalindromesApprox is like FindPalindromesReader but folds the counts
+// of every batch into HeavyHitters, so memory stays fixed however many
+// distinct palindromes rdr holds.
+func (wl *WordLens) FindPalindromesApprox(ctx context.Context, rdr io.Reader, tech Technique, opts ApproxOptions) (*HeavyHitters, error) {
+	hh, err := NewHeavyHitters(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = wl.foldReader(ctx, "WordLens.FindPalindromesApprox", rdr, tech, wl.isPalindrome, false, func(_ batch, res map[string]int, _ error) {
+		for word, n := range res {
+			hh.Add(word, uint64(n))
+		}
+	})
+	return hh, err
+}
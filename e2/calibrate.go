@@ -0,0 +1,214 @@
+package e2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TechniqueAuto picks a technique for each call based on the number of
+// words, using the WordLens' calibration if it has one that was made on a
+// machine with the same number of CPUs and for the same number of workers.
+const TechniqueAuto Technique = "auto"
+
+// DefaultCalibrationSizes are the input sizes timed by WithAutoCalibration.
+var DefaultCalibrationSizes = []int{100, 1_000, 10_000, 100_000}
+
+// autoThreshold is the input size from which TechniqueAuto switches from the
+// sequential to the local technique when there is no usable calibration.
+const autoThreshold = 2_000
+
+// Calibration records which technique was fastest for inputs of up to
+// MaxWords words on a machine with NumCPU CPUs. It can be saved and loaded
+// so the calibration does not have to be repeated on every start.
+type Calibration struct {
+	NumCPU     int         `json:"numCPU"`
+	Workers    int         `json:"workers"`
+	Thresholds []Threshold `json:"thresholds"`
+}
+
+// Threshold records the technique that was fastest for inputs of up to
+// MaxWords words.
+type Threshold struct {
+	MaxWords  int       `json:"maxWords"`
+	Technique Technique `json:"technique"`
+}
+
+// Pick returns the technique calibrated for n words. Inputs larger than the
+// biggest calibrated size use the technique picked for that size.
+func (c Calibration) Pick(n int) Technique {
+	if len(c.Thresholds) == 0 {
+		return TechniqueSequential
+	}
+	for _, t := range c.Thresholds {
+		if n <= t.MaxWords {
+			return t.Technique
+		}
+	}
+	return c.Thresholds[len(c.Thresholds)-1].Technique
+}
+
+// Save writes the calibration as JSON.
+func (c Calibration) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// LoadCalibration reads a calibration written by Calibration.Save.
+func LoadCalibration(r io.Reader) (Calibration, error) {
+	var c Calibration
+	if err := json.NewDecoder(r).Decode(&c); err != nil {
+		return Calibration{}, err
+	}
+	sort.Slice(c.Thresholds, func(i, j int) bool { return c.Thresholds[i].MaxWords < c.Thresholds[j].MaxWords })
+	return c, nil
+}
+
+// WithCalibration makes TechniqueAuto use c, typically loaded with
+// LoadCalibration. It is ignored if c was made with a different number of
+// CPUs or workers.
+func WithCalibration(c Calibration) Option {
+	return func(wl *WordLens) {
+		wl.calibration = &c
+	}
+}
+
+// WithAutoCalibration makes the first call with TechniqueAuto time every
+// registered technique on DefaultCalibrationSizes, which takes up to a few
+// seconds, and use the result from then on. Calibration runs with that
+// call's context; if it fails, e.g. because the context is done, the call
+// returns the error and the next one calibrates again.
+func WithAutoCalibration() Option {
+	return func(wl *WordLens) {
+		wl.autoCalibration = &lazyCalibration{}
+	}
+}
+
+// lazyCalibration is made on first use and shared by every copy of the
+// WordLens it was created for.
+type lazyCalibration struct {
+	mu sync.Mutex
+	c  *Calibration
+}
+
+func (l *lazyCalibration) get(ctx context.Context, wl *WordLens) (*Calibration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.c == nil {
+		c, err := wl.Calibrate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("calibrating: %w", err)
+		}
+		l.c = &c
+	}
+	return l.c, nil
+}
+
+// Calibrate times every registered technique on synthetic inputs of each of
+// the given sizes, which must be positive, or DefaultCalibrationSizes if none
+// are given, and records the fastest one for each size. Calibration runs are
+// neither reported to the WordLens' metrics nor traced.
+func (wl *WordLens) Calibrate(ctx context.Context, sizes ...int) (Calibration, error) {
+	quiet := *wl
+	quiet.metrics, quiet.childSpans = nil, false
+	wl = &quiet
+
+	if len(sizes) == 0 {
+		sizes = DefaultCalibrationSizes
+	}
+	sizes = append([]int(nil), sizes...)
+	sort.Ints(sizes)
+	if sizes[0] < 1 {
+		return Calibration{}, fmt.Errorf("e2: calibration size %d must be positive", sizes[0])
+	}
+
+	c := Calibration{NumCPU: runtime.NumCPU(), Workers: wl.workers}
+	words := calibrationWords(sizes[len(sizes)-1])
+
+	for _, size := range sizes {
+		best, bestTime := TechniqueSequential, time.Duration(-1)
+		for _, tech := range Techniques() {
+			elapsed, err := wl.timeTechnique(ctx, words[:size], tech)
+			if err != nil {
+				return c, err
+			}
+			if bestTime < 0 || elapsed < bestTime {
+				best, bestTime = tech, elapsed
+			}
+		}
+		c.Thresholds = append(c.Thresholds, Threshold{MaxWords: size, Technique: best})
+	}
+	return c, nil
+}
+
+// timeTechnique returns the fastest of a few runs of tech over words.
+func (wl *WordLens) timeTechnique(ctx context.Context, words []string, tech Technique) (time.Duration, error) {
+	const runs = 3
+
+	best := time.Duration(-1)
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		if _, err := wl.count(ctx, words, tech, wl.isPalindrome); err != nil {
+			return 0, err
+		}
+		if elapsed := time.Since(start); best < 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+	return best, nil
+}
+
+// calibrationWords returns n short lowercase words, about one in ten of them
+// a palindrome, from a fixed seed so calibrations are comparable.
+func calibrationWords(n int) []string {
+	rng := rand.New(rand.NewPCG(2680, 2680))
+	words := make([]string, n)
+	for i := range words {
+		b := make([]byte, 1+rng.IntN(10))
+		for j := range b {
+			b[j] = byte('a' + rng.IntN(26))
+		}
+		if rng.IntN(10) == 0 {
+			for j := 0; j < len(b)/2; j++ {
+				b[len(b)-1-j] = b[j]
+			}
+		}
+		words[i] = string(b)
+	}
+	return words
+}
+
+// resolve returns tech, or the technique TechniqueAuto picks for an input
+// of n words, calibrating first if the WordLens was made
+// WithAutoCalibration and isn't calibrated yet. A calibrated technique that
+// is no longer registered is ignored.
+func (wl *WordLens) resolve(ctx context.Context, tech Technique, n int) (Technique, error) {
+	if tech != TechniqueAuto {
+		return tech, nil
+	}
+
+	c := wl.calibration
+	if wl.autoCalibration != nil {
+		var err error
+		if c, err = wl.autoCalibration.get(ctx, wl); err != nil {
+			return tech, err
+		}
+	}
+	if c != nil && c.NumCPU == runtime.NumCPU() && c.Workers == wl.workers {
+		if picked := c.Pick(n); registered(picked) {
+			return picked, nil
+		}
+	}
+	if n < autoThreshold || wl.workers == 1 {
+		return TechniqueSequential, nil
+	}
+	return TechniqueLocal, nil
+}
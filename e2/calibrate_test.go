@@ -0,0 +1,227 @@
+package e2_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCalibrate(t *testing.T) {
+	wl := e2.NewWordLens()
+	c, err := wl.Calibrate(context.Background(), 1000, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if c.NumCPU != runtime.NumCPU() {
+		t.Errorf("Expected NumCPU %d, got %d", runtime.NumCPU(), c.NumCPU)
+	}
+	if len(c.Thresholds) != 2 || c.Thresholds[0].MaxWords != 10 || c.Thresholds[1].MaxWords != 1000 {
+		t.Fatalf("Expected thresholds for 10 and 1000 words, got %+v", c.Thresholds)
+	}
+
+	registered := make(map[e2.Technique]bool)
+	for _, tech := range e2.Techniques() {
+		registered[tech] = true
+	}
+	for _, th := range c.Thresholds {
+		if !registered[th.Technique] {
+			t.Errorf("Expected a registered technique, got %q", th.Technique)
+		}
+	}
+}
+
+func TestCalibrateInvalidSizes(t *testing.T) {
+	tests := map[string][]int{
+		"zero":     {0},
+		"negative": {100, -1},
+	}
+
+	wl := e2.NewWordLens()
+	for name, sizes := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := wl.Calibrate(context.Background(), sizes...); err == nil {
+				t.Errorf("Expected an error calibrating sizes %v", sizes)
+			}
+		})
+	}
+}
+
+func TestCalibrationPick(t *testing.T) {
+	c := e2.Calibration{
+		Thresholds: []e2.Threshold{
+			{MaxWords: 100, Technique: e2.TechniqueSequential},
+			{MaxWords: 10000, Technique: e2.TechniqueLocal},
+		},
+	}
+
+	tests := map[string]struct {
+		n        int
+		expected e2.Technique
+	}{
+		"empty":   {n: 0, expected: e2.TechniqueSequential},
+		"small":   {n: 100, expected: e2.TechniqueSequential},
+		"medium":  {n: 101, expected: e2.TechniqueLocal},
+		"too big": {n: 1000000, expected: e2.TechniqueLocal},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := c.Pick(tc.n); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCalibrationSaveLoad(t *testing.T) {
+	c := e2.Calibration{
+		NumCPU:  runtime.NumCPU(),
+		Workers: 4,
+		Thresholds: []e2.Threshold{
+			{MaxWords: 10000, Technique: e2.TechniqueLocal},
+			{MaxWords: 100, Technique: e2.TechniqueSequential},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := c.Save(&buf); err != nil {
+		t.Fatalf("Expected no error saving, got %v", err)
+	}
+	loaded, err := e2.LoadCalibration(&buf)
+	if err != nil {
+		t.Fatalf("Expected no error loading, got %v", err)
+	}
+
+	// thresholds come back sorted by size
+	c.Thresholds[0], c.Thresholds[1] = c.Thresholds[1], c.Thresholds[0]
+	if !reflect.DeepEqual(loaded, c) {
+		t.Errorf("Expected %+v, got %+v", c, loaded)
+	}
+}
+
+func TestTechniqueAuto(t *testing.T) {
+	words := wordlens.TestWords()
+
+	tests := map[string]struct {
+		calibration *e2.Calibration
+		expected    e2.Technique
+	}{
+		"heuristic": {
+			expected: e2.TechniqueSequential,
+		},
+		"calibration": {
+			calibration: &e2.Calibration{
+				NumCPU:     runtime.NumCPU(),
+				Workers:    4,
+				Thresholds: []e2.Threshold{{MaxWords: 10, Technique: e2.TechniqueWorkers}},
+			},
+			expected: e2.TechniqueWorkers,
+		},
+		"other machine": {
+			calibration: &e2.Calibration{
+				NumCPU:     runtime.NumCPU() + 1,
+				Workers:    4,
+				Thresholds: []e2.Threshold{{MaxWords: 10, Technique: e2.TechniqueWorkers}},
+			},
+			expected: e2.TechniqueSequential,
+		},
+		"other workers": {
+			calibration: &e2.Calibration{
+				NumCPU:     runtime.NumCPU(),
+				Workers:    1,
+				Thresholds: []e2.Threshold{{MaxWords: 10, Technique: e2.TechniqueWorkers}},
+			},
+			expected: e2.TechniqueSequential,
+		},
+		"missing": {
+			calibration: &e2.Calibration{
+				NumCPU:     runtime.NumCPU(),
+				Workers:    4,
+				Thresholds: []e2.Threshold{{MaxWords: 10, Technique: "missing"}},
+			},
+			expected: e2.TechniqueSequential,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reg := prometheus.NewRegistry()
+			m, err := e2.NewMetrics(reg)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			opts := []e2.Option{e2.WithWorkers(4), e2.WithMetrics(m)}
+			if tc.calibration != nil {
+				opts = append(opts, e2.WithCalibration(*tc.calibration))
+			}
+
+			wl := e2.NewWordLens(opts...)
+			res := wl.FindPalindromes(words, true, e2.TechniqueAuto)
+			if len(res) != 27 {
+				t.Errorf("Expected 27 palindromes, got %d", len(res))
+			}
+
+			families, err := reg.Gather()
+			if err != nil {
+				t.Fatalf("Expected no error gathering, got %v", err)
+			}
+			if got, _ := metricValue(families, "wordlens_words_processed_total", string(tc.expected)); got != float64(len(words)) {
+				t.Errorf("Expected %s to check %d words, got %v", tc.expected, len(words), got)
+			}
+		})
+	}
+}
+
+func TestWithAutoCalibration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("calibration takes a while")
+	}
+
+	reg := prometheus.NewRegistry()
+	m, err := e2.NewMetrics(reg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	wl := e2.NewWordLens(e2.WithAutoCalibration(), e2.WithMetrics(m))
+	words := wordlens.TestWords()
+
+	// calibration runs with the caller's context and is retried after failing
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := wl.FindPalindromesContext(ctx, words, e2.TechniqueAuto); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, got %v", err)
+	}
+
+	start := time.Now()
+	res, err := wl.FindPalindromesContext(context.Background(), words, e2.TechniqueAuto)
+	t.Logf("calibrated in %s", time.Since(start))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(res) != 27 {
+		t.Errorf("Expected 27 palindromes, got %d", len(res))
+	}
+
+	// only the call itself is reported, not the calibration runs
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error gathering, got %v", err)
+	}
+	var checked float64
+	for _, tech := range e2.Techniques() {
+		n, _ := metricValue(families, "wordlens_words_processed_total", string(tech))
+		checked += n
+	}
+	if checked != float64(len(words)) {
+		t.Errorf("Expected %d words to be reported, got %v", len(words), checked)
+	}
+}
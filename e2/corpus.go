@@ -0,0 +1,143 @@
+package e2
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// CorpusOptions control FindPalindromesCorpus. Parallel is the number of
+// files read at once and defaults to the WordLens' workers. Pattern, when
+// set, is matched against the base name of every file, e.g. "*.txt".
+type CorpusOptions struct {
+	Parallel int
+	Pattern  string
+}
+
+// Document is the result for one file of a corpus. Err is set when the file
+// could not be fully read, in which case Counts holds what was counted.
+type Document struct {
+	Path   string `json:"path"`
+	Counts Counts `json:"counts"`
+	Err    error  `json:"-"`
+}
+
+// Corpus holds the counts of every document and their sum.
+type Corpus struct {
+	Documents []Document `json:"documents"`
+	Total     Counts     `json:"total"`
+}
+
+// Failed returns the documents that could not be fully read.
+func (c *Corpus) Failed() []Document {
+	var failed []Document
+	for _, doc := range c.Documents {
+		if doc.Err != nil {
+			failed = append(failed, doc)
+		}
+	}
+	return failed
+}
+
+// FindPalindromesCorpus walks root, which may also be a single file, and
+// runs FindPalindromesReader with tech over every file. A file that can't
+// be read is reported in its Document and doesn't stop the others; an error
+// is only returned when root can't be walked at all or ctx is done.
+// Documents are sorted by path.
+func (wl *WordLens) FindPalindromesCorpus(ctx context.Context, root string, tech Technique, opts CorpusOptions) (*Corpus, error) {
+	if _, err := os.Lstat(root); err != nil {
+		return nil, err
+	}
+	if opts.Parallel <= 0 {
+		opts.Parallel = wl.workers
+	}
+
+	paths := make(chan string)
+	docs := make(chan Document)
+
+	// walk
+	var walkErr error
+	go func() {
+		defer close(paths)
+		walkErr = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				// report the unreadable directory and carry on
+				select {
+				case docs <- Document{Path: path, Err: err}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				return nil
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			if opts.Pattern != "" {
+				if ok, err := filepath.Match(opts.Pattern, d.Name()); err != nil || !ok {
+					return err
+				}
+			}
+
+			select {
+			case paths <- path:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+
+	// count
+	wg := sync.WaitGroup{}
+	wg.Add(opts.Parallel)
+	for i := 0; i < opts.Parallel; i++ {
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				docs <- wl.document(ctx, path, tech)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(docs)
+	}()
+
+	// merge
+	corpus := &Corpus{Total: make(Counts)}
+	for doc := range docs {
+		corpus.Documents = append(corpus.Documents, doc)
+		corpus.Total.Merge(doc.Counts)
+	}
+	slices.SortFunc(corpus.Documents, func(a, b Document) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	if err := ctx.Err(); err != nil {
+		return corpus, fmt.Errorf("%w: %w", ErrIncomplete, err)
+	}
+	if walkErr != nil {
+		return corpus, walkErr
+	}
+	return corpus, nil
+}
+
+func (wl *WordLens) document(ctx context.Context, path string, tech Technique) Document {
+	f, err := os.Open(path)
+	if err != nil {
+		return Document{Path: path, Err: err}
+	}
+	defer f.Close()
+
+	counts, err := wl.FindPalindromesReader(ctx, f, tech)
+	if err != nil && !errors.Is(err, ErrIncomplete) {
+		err = fmt.Errorf("%s: %w", path, err)
+	}
+	return Document{Path: path, Counts: counts, Err: err}
+}
@@ -0,0 +1,104 @@
+package e2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+func writeCorpus(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestFindPalindromesCorpus(t *testing.T) {
+	dir := writeCorpus(t, map[string]string{
+		"a.txt":      "noon level noon",
+		"sub/b.txt":  "did noon",
+		"sub/c.md":   "kayak",
+		"broken.txt": "noon " + strings.Repeat("xy", tokenize.MaxWordSize/2+1), // longer than a word may be
+	})
+
+	tests := map[string]struct {
+		root          string
+		opts          e2.CorpusOptions
+		expectedPaths []string
+		expectedTotal e2.Counts
+		failed        int
+	}{
+		"directory": {
+			root:          dir,
+			expectedPaths: []string{"a.txt", "broken.txt", "sub/b.txt", "sub/c.md"},
+			expectedTotal: e2.Counts{"noon": 4, "level": 1, "did": 1, "kayak": 1},
+			failed:        1,
+		},
+		"pattern": {
+			root:          dir,
+			opts:          e2.CorpusOptions{Parallel: 1, Pattern: "*.md"},
+			expectedPaths: []string{"sub/c.md"},
+			expectedTotal: e2.Counts{"kayak": 1},
+		},
+		"single file": {
+			root:          filepath.Join(dir, "a.txt"),
+			expectedPaths: []string{"a.txt"},
+			expectedTotal: e2.Counts{"noon": 2, "level": 1},
+		},
+	}
+
+	wl := e2.NewWordLens(e2.WithWorkers(2))
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			corpus, err := wl.FindPalindromesCorpus(context.Background(), tc.root, e2.TechniqueLocal, tc.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var paths []string
+			for _, doc := range corpus.Documents {
+				rel, _ := filepath.Rel(dir, doc.Path)
+				paths = append(paths, filepath.ToSlash(rel))
+			}
+			if !reflect.DeepEqual(paths, tc.expectedPaths) {
+				t.Errorf("Expected %v, got %v", tc.expectedPaths, paths)
+			}
+			if !reflect.DeepEqual(corpus.Total, tc.expectedTotal) {
+				t.Errorf("Expected %v, got %v", tc.expectedTotal, corpus.Total)
+			}
+			if got := len(corpus.Failed()); got != tc.failed {
+				t.Errorf("Expected %d failed documents, got %d", tc.failed, got)
+			}
+		})
+	}
+}
+
+func TestFindPalindromesCorpusErrors(t *testing.T) {
+	wl := e2.NewWordLens()
+
+	if _, err := wl.FindPalindromesCorpus(context.Background(), "missing", e2.TechniqueSequential, e2.CorpusOptions{}); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dir := writeCorpus(t, map[string]string{"a.txt": "noon"})
+	if _, err := wl.FindPalindromesCorpus(ctx, dir, e2.TechniqueSequential, e2.CorpusOptions{}); !errors.Is(err, e2.ErrIncomplete) {
+		t.Errorf("Expected ErrIncomplete, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package e2
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Counts is how often each word was found. It can be combined with the
+// counts of other documents, e.g. the results of FindPalindromesReader.
+type Counts map[string]int
+
+// WordCount is a word and the number of times it was found.
+type WordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Delta is how a word's count changed from one Counts to another.
+type Delta struct {
+	Word string `json:"word"`
+	From int    `json:"from"`
+	To   int    `json:"to"`
+}
+
+// Merge adds the counts of o to c.
+func (c Counts) Merge(o Counts) {
+	for word, n := range o {
+		c[word] += n
+	}
+}
+
+// Subtract removes the counts of o from c, dropping words whose count
+// reaches zero.
+func (c Counts) Subtract(o Counts) {
+	for word, n := range o {
+		if c[word] <= n {
+			delete(c, word)
+			continue
+		}
+		c[word] -= n
+	}
+}
+
+// Total is the sum of every count.
+func (c Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+// TopN returns the n most frequent words, or all of them when n is 0. Ties
+// are broken alphabetically so the order is stable.
+func (c Counts) TopN(n int) []WordCount {
+	top := make([]WordCount, 0, len(c))
+	for word, count := range c {
+		top = append(top, WordCount{Word: word, Count: count})
+	}
+	slices.SortFunc(top, func(a, b WordCount) int {
+		if n := cmp.Compare(b.Count, a.Count); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+
+	if n > 0 && n < len(top) {
+		top = top[:n]
+	}
+	return top
+}
+
+// Diff returns every word whose count differs between c and o, the largest
+// changes first.
+func (c Counts) Diff(o Counts) []Delta {
+	var deltas []Delta
+	for word, n := range c {
+		if o[word] != n {
+			deltas = append(deltas, Delta{Word: word, From: n, To: o[word]})
+		}
+	}
+	for word, n := range o {
+		if _, ok := c[word]; !ok {
+			deltas = append(deltas, Delta{Word: word, To: n})
+		}
+	}
+
+	abs := func(d Delta) int { return max(d.To-d.From, d.From-d.To) }
+	slices.SortFunc(deltas, func(a, b Delta) int {
+		if n := cmp.Compare(abs(b), abs(a)); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+	return deltas
+}
@@ -0,0 +1,69 @@
+package e2_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestCounts(t *testing.T) {
+	a := e2.Counts{"noon": 3, "level": 1, "did": 2}
+	b := e2.Counts{"noon": 1, "level": 1, "kayak": 4}
+
+	tests := map[string]struct {
+		got      any
+		expected any
+	}{
+		"merge": {
+			got: func() e2.Counts {
+				c := e2.Counts{}
+				c.Merge(a)
+				c.Merge(b)
+				return c
+			}(),
+			expected: e2.Counts{"noon": 4, "level": 2, "did": 2, "kayak": 4},
+		},
+		"subtract": {
+			got: func() e2.Counts {
+				c := e2.Counts{}
+				c.Merge(a)
+				c.Subtract(b)
+				return c
+			}(),
+			expected: e2.Counts{"noon": 2, "did": 2},
+		},
+		"total": {
+			got:      a.Total(),
+			expected: 6,
+		},
+		"top 2": {
+			got:      a.TopN(2),
+			expected: []e2.WordCount{{Word: "noon", Count: 3}, {Word: "did", Count: 2}},
+		},
+		"top all with ties": {
+			got:      b.TopN(0),
+			expected: []e2.WordCount{{Word: "kayak", Count: 4}, {Word: "level", Count: 1}, {Word: "noon", Count: 1}},
+		},
+		"diff": {
+			got: a.Diff(b),
+			expected: []e2.Delta{
+				{Word: "kayak", From: 0, To: 4},
+				{Word: "did", From: 2, To: 0},
+				{Word: "noon", From: 3, To: 1},
+			},
+		},
+		"no diff": {
+			got:      a.Diff(a),
+			expected: []e2.Delta(nil),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !reflect.DeepEqual(tc.got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, tc.got)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package e2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// indexVersion is bumped whenever the meaning of stored counts changes, so
+// older indexes are rebuilt instead of mixed with new counts.
+const indexVersion = 2
+
+// Index is a persistent record of the palindromes in a set of documents. It
+// is kept up to date with Sync, which only rescans documents whose content
+// changed, and answers queries from the stored counts alone.
+type Index struct {
+	Version    int                    `json:"version"`
+	Normalizer string                 `json:"normalizer"`
+	Tokenizer  string                 `json:"tokenizer"`
+	Documents  map[string]*IndexEntry `json:"documents"`
+
+	total Counts
+}
+
+// IndexEntry is what the index knows about one document. Size and ModTime
+// let Sync skip hashing files that were not touched.
+type IndexEntry struct {
+	Hash    string    `json:"hash"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Counts  Counts    `json:"counts"`
+}
+
+// SyncStats reports what a Sync changed. Errors holds one error per
+// document that could not be read; those keep their previous counts.
+type SyncStats struct {
+	Added     []string
+	Updated   []string
+	Removed   []string
+	Unchanged int
+	Errors    []error
+}
+
+func NewIndex() *Index {
+	return &Index{
+		Version:   indexVersion,
+		Documents: make(map[string]*IndexEntry),
+		total:     make(Counts),
+	}
+}
+
+// LoadIndex reads an index written by Index.Save.
+func LoadIndex(r io.Reader) (*Index, error) {
+	ix := NewIndex()
+	if err := json.NewDecoder(r).Decode(ix); err != nil {
+		return nil, err
+	}
+	if ix.Version != indexVersion {
+		return nil, fmt.Errorf("e2: index version %d, expected %d", ix.Version, indexVersion)
+	}
+	if ix.Documents == nil {
+		ix.Documents = make(map[string]*IndexEntry)
+	}
+	for _, entry := range ix.Documents {
+		ix.total.Merge(entry.Counts)
+	}
+	return ix, nil
+}
+
+// OpenIndex loads the index at path, or returns an empty one if there is
+// no file there yet.
+func OpenIndex(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewIndex(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadIndex(f)
+}
+
+func (ix *Index) Save(w io.Writer) error {
+	return json.NewEncoder(w).Encode(ix)
+}
+
+// WriteFile saves the index to path atomically, so a crash never leaves a
+// truncated index behind.
+func (ix *Index) WriteFile(path string) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) // fails harmlessly once renamed
+
+	if err := ix.Save(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// Total returns the counts of every document combined.
+func (ix *Index) Total() Counts {
+	return ix.total
+}
+
+// TopN returns the n most frequent palindromes across every document.
+func (ix *Index) TopN(n int) []WordCount {
+	return ix.total.TopN(n)
+}
+
+// Lookup returns how often word appears in each document that has it.
+func (ix *Index) Lookup(word string) map[string]int {
+	docs := make(map[string]int)
+	for path, entry := range ix.Documents {
+		if n := entry.Counts[word]; n > 0 {
+			docs[path] = n
+		}
+	}
+	return docs
+}
+
+// Remove drops the document at path from the index.
+func (ix *Index) Remove(path string) bool {
+	entry, ok := ix.Documents[path]
+	if !ok {
+		return false
+	}
+	ix.total.Subtract(entry.Counts)
+	delete(ix.Documents, path)
+	return true
+}
+
+func (ix *Index) put(path string, entry *IndexEntry) {
+	ix.Remove(path)
+	ix.Documents[path] = entry
+	ix.total.Merge(entry.Counts)
+}
+
+// Sync brings the documents under root, which may also be a single file, up
+// to date: new and changed files are counted with tech, files that are gone
+// are removed and the rest is left alone. If wl normalizes or tokenizes
+// differently from the WordLens that built the index, or its normalizer
+// isn't a fmt.Stringer and so can't be told apart from others, every
+// document is counted again, whichever root it was synced from. Documents
+// that can't be counted again are dropped and reported as removed.
+func (wl *WordLens) Sync(ctx context.Context, ix *Index, root string, tech Technique) (stats SyncStats, err error) {
+	root = filepath.Clean(root)
+	if _, err := os.Lstat(root); err != nil {
+		return stats, err
+	}
+
+	// documents dropped by a rebuild are counted again, or else reported as
+	// removed
+	var recount []string
+	normalizer, tokenizer := wl.normalizerName(), wl.tokens.String()
+	if normalizer == "" || ix.Normalizer != normalizer || ix.Tokenizer != tokenizer {
+		for path := range ix.Documents {
+			ix.Remove(path)
+			recount = append(recount, path)
+		}
+		slices.Sort(recount)
+		ix.Normalizer, ix.Tokenizer = normalizer, tokenizer
+	}
+	defer func() {
+		for _, path := range recount {
+			if ix.Documents[path] == nil {
+				stats.Removed = append(stats.Removed, path)
+			}
+		}
+		slices.Sort(stats.Removed)
+	}()
+
+	seen := make(map[string]bool)
+	visit := func(path string) error {
+		status, err := wl.syncFile(ctx, ix, path, tech)
+		switch {
+		case errors.Is(err, ErrIncomplete):
+			return err
+		case errors.Is(err, fs.ErrNotExist) && !seen[path]:
+			// a document of another root that is gone
+		case err != nil:
+			stats.Errors = append(stats.Errors, fmt.Errorf("%s: %w", path, err))
+		case status == added:
+			stats.Added = append(stats.Added, path)
+		case status == updated:
+			stats.Updated = append(stats.Updated, path)
+		default:
+			stats.Unchanged++
+		}
+		return nil
+	}
+
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			stats.Errors = append(stats.Errors, err)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		seen[path] = true
+		return visit(path)
+	})
+	if err != nil {
+		// documents that weren't reached yet must not be removed
+		return stats, err
+	}
+
+	for _, path := range recount {
+		if !within(root, path) {
+			if err := visit(path); err != nil {
+				return stats, err
+			}
+		}
+	}
+	for path := range ix.Documents {
+		if !seen[path] && within(root, path) {
+			ix.Remove(path)
+			stats.Removed = append(stats.Removed, path)
+		}
+	}
+	return stats, nil
+}
+
+type syncStatus int
+
+const (
+	unchanged syncStatus = iota
+	added
+	updated
+)
+
+// syncFile counts the file at path again unless its content is the same as
+// when it was indexed.
+func (wl *WordLens) syncFile(ctx context.Context, ix *Index, path string, tech Technique) (syncStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return unchanged, fmt.Errorf("%w: %w", ErrIncomplete, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return unchanged, err
+	}
+	old := ix.Documents[path]
+	if old != nil && old.Size == info.Size() && old.ModTime.Equal(info.ModTime()) {
+		return unchanged, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return unchanged, err
+	}
+	defer f.Close()
+
+	if old != nil {
+		h := sha256.New()
+		if _, err := io.Copy(h, f); err != nil {
+			return unchanged, err
+		}
+		if hex.EncodeToString(h.Sum(nil)) == old.Hash {
+			// touched but not modified
+			old.Size, old.ModTime = info.Size(), info.ModTime()
+			return unchanged, nil
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return unchanged, err
+		}
+	}
+
+	// hash what is actually counted, in case the file changes meanwhile
+	h := sha256.New()
+	counts, err := wl.FindPalindromesReader(ctx, io.TeeReader(f, h), tech)
+	if err != nil {
+		return unchanged, err
+	}
+	ix.put(path, &IndexEntry{
+		Hash:    hex.EncodeToString(h.Sum(nil)),
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+		Counts:  counts,
+	})
+	if old == nil {
+		return added, nil
+	}
+	return updated, nil
+}
+
+// within reports whether path is root or below it.
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// normalizerName identifies the WordLens' normalizer so an index can tell
+// whether its counts were made the same way. It is empty for normalizers
+// that don't name themselves with a String method.
+func (wl *WordLens) normalizerName() string {
+	switch n := wl.normalizer.(type) {
+	case nil:
+		return "none"
+	case fmt.Stringer:
+		return n.String()
+	default:
+		return ""
+	}
+}
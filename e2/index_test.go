@@ -0,0 +1,252 @@
+package e2_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+func TestIndexSync(t *testing.T) {
+	dir := writeCorpus(t, map[string]string{
+		"a.txt":     "noon level noon",
+		"sub/b.txt": "did Noon",
+	})
+	a, b := filepath.Join(dir, "a.txt"), filepath.Join(dir, "sub", "b.txt")
+	ctx := context.Background()
+	wl := e2.NewWordLens()
+	ix := e2.NewIndex()
+
+	tests := []struct {
+		name     string
+		change   func(t *testing.T)
+		wl       e2.WordLens
+		expected e2.SyncStats
+		total    e2.Counts
+	}{
+		{
+			name:     "new documents",
+			change:   func(t *testing.T) {},
+			wl:       wl,
+			expected: e2.SyncStats{Added: []string{a, b}},
+			total:    e2.Counts{"noon": 2, "level": 1, "did": 1},
+		},
+		{
+			name:     "nothing changed",
+			change:   func(t *testing.T) {},
+			wl:       wl,
+			expected: e2.SyncStats{Unchanged: 2},
+			total:    e2.Counts{"noon": 2, "level": 1, "did": 1},
+		},
+		{
+			name: "touched",
+			change: func(t *testing.T) {
+				later := time.Now().Add(time.Hour)
+				if err := os.Chtimes(a, later, later); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wl:       wl,
+			expected: e2.SyncStats{Unchanged: 2},
+			total:    e2.Counts{"noon": 2, "level": 1, "did": 1},
+		},
+		{
+			name: "modified",
+			change: func(t *testing.T) {
+				if err := os.WriteFile(a, []byte("kayak level"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wl:       wl,
+			expected: e2.SyncStats{Updated: []string{a}, Unchanged: 1},
+			total:    e2.Counts{"kayak": 1, "level": 1, "did": 1},
+		},
+		{
+			name: "removed",
+			change: func(t *testing.T) {
+				if err := os.Remove(a); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wl:       wl,
+			expected: e2.SyncStats{Removed: []string{a}, Unchanged: 1},
+			total:    e2.Counts{"did": 1},
+		},
+		{
+			name:     "different normalizer",
+			change:   func(t *testing.T) {},
+			wl:       e2.NewWordLens(e2.WithNormalizer(normalize.FoldCase)),
+			expected: e2.SyncStats{Added: []string{b}},
+			total:    e2.Counts{"did": 1, "Noon": 1},
+		},
+		{
+			name:   "different tokenizer",
+			change: func(t *testing.T) {},
+			wl: e2.NewWordLens(
+				e2.WithNormalizer(normalize.FoldCase),
+				e2.WithTokenizer(tokenize.StripPunctuation),
+			),
+			expected: e2.SyncStats{Added: []string{b}},
+			total:    e2.Counts{"did": 1, "Noon": 1},
+		},
+		// unnamed normalizers can't be told apart, so they never reuse counts
+		{
+			name:   "unnamed normalizer",
+			change: func(t *testing.T) {},
+			wl: e2.NewWordLens(
+				e2.WithNormalizer(normalize.Func(strings.ToUpper)),
+				e2.WithTokenizer(tokenize.StripPunctuation),
+			),
+			expected: e2.SyncStats{Added: []string{b}},
+			total:    e2.Counts{"did": 1, "Noon": 1},
+		},
+		{
+			name:   "another unnamed normalizer",
+			change: func(t *testing.T) {},
+			wl: e2.NewWordLens(
+				e2.WithNormalizer(normalize.Func(strings.ToLower)),
+				e2.WithTokenizer(tokenize.StripPunctuation),
+			),
+			expected: e2.SyncStats{Added: []string{b}},
+			total:    e2.Counts{"did": 1, "Noon": 1},
+		},
+	}
+
+	// steps build on each other, so they run in order
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.change(t)
+			stats, err := tc.wl.Sync(ctx, ix, dir, e2.TechniqueSequential)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(stats, tc.expected) {
+				t.Errorf("Expected %+v, got %+v", tc.expected, stats)
+			}
+			if !reflect.DeepEqual(ix.Total(), tc.total) {
+				t.Errorf("Expected %v, got %v", tc.total, ix.Total())
+			}
+		})
+	}
+}
+
+// TestIndexSyncRoots checks that a rebuild counts documents synced from
+// other roots again instead of dropping them.
+func TestIndexSyncRoots(t *testing.T) {
+	lower := e2.NewWordLens(e2.WithNormalizer(normalize.Func(strings.ToLower)))
+	tests := map[string]struct {
+		before e2.WordLens
+		after  e2.WordLens
+	}{
+		"different tokenizer": {
+			before: e2.NewWordLens(),
+			after:  e2.NewWordLens(e2.WithTokenizer(tokenize.All)),
+		},
+		"unnamed normalizer": {
+			before: lower,
+			after:  lower,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeCorpus(t, map[string]string{
+				"a/x.txt":    "noon",
+				"a/gone.txt": "wow",
+				"b/y.txt":    "level",
+			})
+			a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+			ctx := context.Background()
+			ix := e2.NewIndex()
+			for _, root := range []string{a, b} {
+				if _, err := tc.before.Sync(ctx, ix, root, e2.TechniqueSequential); err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+			}
+			if err := os.Remove(filepath.Join(a, "gone.txt")); err != nil {
+				t.Fatal(err)
+			}
+
+			stats, err := tc.after.Sync(ctx, ix, b, e2.TechniqueSequential)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			expected := e2.SyncStats{
+				Added:   []string{filepath.Join(b, "y.txt"), filepath.Join(a, "x.txt")},
+				Removed: []string{filepath.Join(a, "gone.txt")},
+			}
+			if !reflect.DeepEqual(stats, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, stats)
+			}
+			total := e2.Counts{"noon": 1, "level": 1}
+			if !reflect.DeepEqual(ix.Total(), total) {
+				t.Errorf("Expected %v, got %v", total, ix.Total())
+			}
+		})
+	}
+}
+
+func TestIndexQueries(t *testing.T) {
+	dir := writeCorpus(t, map[string]string{
+		"a.txt": "noon level noon",
+		"b.txt": "did noon",
+	})
+	wl := e2.NewWordLens()
+	ix := e2.NewIndex()
+	if _, err := wl.Sync(context.Background(), ix, dir, e2.TechniqueLocal); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	path := filepath.Join(dir, "index.json")
+	if err := ix.WriteFile(path); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	loaded, err := e2.OpenIndex(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedTop := []e2.WordCount{{Word: "noon", Count: 3}, {Word: "did", Count: 1}}
+	if got := loaded.TopN(2); !reflect.DeepEqual(got, expectedTop) {
+		t.Errorf("Expected %v, got %v", expectedTop, got)
+	}
+	expectedDocs := map[string]int{filepath.Join(dir, "a.txt"): 2, filepath.Join(dir, "b.txt"): 1}
+	if got := loaded.Lookup("noon"); !reflect.DeepEqual(got, expectedDocs) {
+		t.Errorf("Expected %v, got %v", expectedDocs, got)
+	}
+
+	// the index itself is now in dir but unchanged documents are not rescanned
+	stats, err := wl.Sync(context.Background(), loaded, dir, e2.TechniqueLocal)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stats.Unchanged != 2 || len(stats.Updated) != 0 {
+		t.Errorf("Expected 2 unchanged documents, got %+v", stats)
+	}
+}
+
+func TestOpenIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	ix, err := e2.OpenIndex(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ix.Documents) != 0 {
+		t.Errorf("Expected an empty index, got %d documents", len(ix.Documents))
+	}
+
+	// version 1 indexes were made before tokenizers were configurable
+	for _, data := range []string{`{"version": 1}`, `{"version": 99}`} {
+		if _, err := e2.LoadIndex(strings.NewReader(data)); err == nil {
+			t.Errorf("Expected an error loading %s", data)
+		}
+	}
+}
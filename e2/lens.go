@@ -0,0 +1,128 @@
+package e2
+
+import (
+	"context"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+)
+
+// Lens is an analysis that can run on any Technique. Analyze is called for
+// every word, possibly from many goroutines at once, and reports whether the
+// word should be counted. Aggregate then turns the counts of the selected
+// words into the lens' result.
+type Lens[T any] interface {
+	Analyze(word string) bool
+	Aggregate(counts map[string]int) T
+}
+
+// Analyze runs lens over words using tech.
+func Analyze[T any](wl *WordLens, words []string, tech Technique, lens Lens[T]) T {
+	res, _ := AnalyzeContext(context.Background(), wl, words, tech, lens)
+	return res
+}
+
+// AnalyzeContext is like Analyze but stops once ctx is done, aggregating the
+// partial counts and returning an error matching ErrIncomplete.
+func AnalyzeContext[T any](ctx context.Context, wl *WordLens, words []string, tech Technique, lens Lens[T]) (T, error) {
+	counts, err := wl.traced(ctx, "WordLens.Analyze", words, tech, lens.Analyze)
+	return lens.Aggregate(counts), err
+}
+
+// AnalyzeReader runs lens over the words read from rdr using tech, with the
+// same bounded memory use as FindPalindromesReader.
+func AnalyzeReader[T any](ctx context.Context, wl *WordLens, rdr io.Reader, tech Technique, lens Lens[T]) (T, error) {
+	counts, err := wl.countReader(ctx, "WordLens.AnalyzeReader", rdr, tech, lens.Analyze)
+	return lens.Aggregate(counts), err
+}
+
+// PalindromeLens returns the lens behind FindPalindromes, using the
+// WordLens' normalizer.
+func (wl *WordLens) PalindromeLens() Lens[map[string]int] {
+	return palindromeLens{wl: wl}
+}
+
+type palindromeLens struct {
+	wl *WordLens
+}
+
+func (l palindromeLens) Analyze(word string) bool {
+	return l.wl.isPalindrome(word)
+}
+
+func (l palindromeLens) Aggregate(counts map[string]int) map[string]int {
+	return counts
+}
+
+// AnagramClass is a set of words made of the same letters, e.g. "listen"
+// and "silent". Words are normalized and Key is their sorted letters.
+type AnagramClass struct {
+	Key   string         `json:"key"`
+	Words map[string]int `json:"words"`
+	Total int            `json:"total"`
+}
+
+// AnagramLens groups words into anagram classes. Words are compared after
+// applying Normalizer, which defaults to folding case and stripping
+// punctuation, and only classes with at least MinWords distinct words are
+// reported (2 if unset).
+type AnagramLens struct {
+	Normalizer normalize.Normalizer
+	MinWords   int
+}
+
+func (l AnagramLens) normalize(word string) string {
+	if l.Normalizer == nil {
+		return (normalize.FoldCase | normalize.StripPunctuation).Normalize(word)
+	}
+	return l.Normalizer.Normalize(word)
+}
+
+func sortRunes(word string) string {
+	runes := []rune(word)
+	slices.Sort(runes)
+	return string(runes)
+}
+
+func (l AnagramLens) Analyze(word string) bool {
+	return l.normalize(word) != ""
+}
+
+// Aggregate returns the anagram classes ordered by total count, largest
+// first, then by key.
+func (l AnagramLens) Aggregate(counts map[string]int) []AnagramClass {
+	minWords := l.MinWords
+	if minWords < 1 {
+		minWords = 2
+	}
+
+	byKey := make(map[string]*AnagramClass)
+	for word, n := range counts {
+		word = l.normalize(word)
+		key := sortRunes(word)
+		class, ok := byKey[key]
+		if !ok {
+			class = &AnagramClass{Key: key, Words: make(map[string]int)}
+			byKey[key] = class
+		}
+		class.Words[word] += n
+		class.Total += n
+	}
+
+	var classes []AnagramClass
+	for _, class := range byKey {
+		if len(class.Words) >= minWords {
+			classes = append(classes, *class)
+		}
+	}
+
+	sort.Slice(classes, func(i, j int) bool {
+		if classes[i].Total != classes[j].Total {
+			return classes[i].Total > classes[j].Total
+		}
+		return classes[i].Key < classes[j].Key
+	})
+	return classes
+}
@@ -0,0 +1,82 @@
+package e2_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestPalindromeLens(t *testing.T) {
+	wl := e2.NewWordLens()
+	words := wordlens.TestWords()
+	expected := wl.FindPalindromes(words, false, e2.TechniqueSequential)
+
+	for _, tech := range e2.Techniques() {
+		t.Run(string(tech), func(t *testing.T) {
+			res := e2.Analyze(&wl, words, tech, wl.PalindromeLens())
+			if !reflect.DeepEqual(res, expected) {
+				t.Errorf("Expected %v, got %v", expected, res)
+			}
+		})
+	}
+}
+
+func TestAnagramLens(t *testing.T) {
+	tests := map[string]struct {
+		words    []string
+		lens     e2.AnagramLens
+		expected []e2.AnagramClass
+	}{
+		"empty": {
+			words:    []string{},
+			expected: nil,
+		},
+		"classes": {
+			words: []string{"Listen,", "silent", "enlist", "listen", "evil", "vile", "live.", "hello", "--"},
+			expected: []e2.AnagramClass{
+				{Key: "eilnst", Words: map[string]int{"listen": 2, "silent": 1, "enlist": 1}, Total: 4},
+				{Key: "eilv", Words: map[string]int{"evil": 1, "vile": 1, "live": 1}, Total: 3},
+			},
+		},
+		"min words": {
+			words: []string{"listen", "silent", "enlist", "evil", "vile"},
+			lens:  e2.AnagramLens{MinWords: 3},
+			expected: []e2.AnagramClass{
+				{Key: "eilnst", Words: map[string]int{"listen": 1, "silent": 1, "enlist": 1}, Total: 3},
+			},
+		},
+	}
+
+	wl := e2.NewWordLens()
+
+	for name, tc := range tests {
+		for _, tech := range e2.Techniques() {
+			t.Run(name+"/"+string(tech), func(t *testing.T) {
+				res := e2.Analyze(&wl, tc.words, tech, tc.lens)
+				if !reflect.DeepEqual(res, tc.expected) {
+					t.Errorf("Expected %+v, got %+v", tc.expected, res)
+				}
+			})
+		}
+	}
+}
+
+func TestAnalyzeReader(t *testing.T) {
+	wl := e2.NewWordLens()
+	res, err := e2.AnalyzeReader(context.Background(), &wl, strings.NewReader("stressed desserts\nrats star arts"), e2.TechniqueWorkers, e2.AnagramLens{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []e2.AnagramClass{
+		{Key: "arst", Words: map[string]int{"rats": 1, "star": 1, "arts": 1}, Total: 3},
+		{Key: "deerssst", Words: map[string]int{"stressed": 1, "desserts": 1}, Total: 2},
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, res)
+	}
+}
@@ -0,0 +1,93 @@
+package e2
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the Prometheus collectors a WordLens reports to when
+// created WithMetrics. Every collector is labelled with the technique that
+// ran, after TechniqueAuto has been resolved.
+type Metrics struct {
+	words      *prometheus.CounterVec
+	matches    *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+	goroutines *prometheus.CounterVec
+	queueDepth *prometheus.GaugeVec
+}
+
+// NewMetrics creates the wordlens collectors and registers them on reg,
+// e.g. prometheus.DefaultRegisterer to have them served by promhttp.Handler.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		words: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordlens_words_processed_total",
+			Help: "Number of words checked by a technique.",
+		}, []string{"technique"}),
+		matches: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordlens_palindromes_found_total",
+			Help: "Number of words matched by a technique, i.e. palindromes unless a different Lens was used.",
+		}, []string{"technique"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wordlens_technique_duration_seconds",
+			Help:    "Time taken by a technique to check a batch of words.",
+			Buckets: prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"technique"}),
+		goroutines: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordlens_goroutines_spawned_total",
+			Help: "Number of goroutines started by a technique.",
+		}, []string{"technique"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "wordlens_worker_queue_depth",
+			Help: "Number of items waiting in a technique's queue.",
+		}, []string{"technique"}),
+	}
+
+	var errs []error
+	for _, c := range []prometheus.Collector{m.words, m.matches, m.duration, m.goroutines, m.queueDepth} {
+		errs = append(errs, reg.Register(c))
+	}
+	return m, errors.Join(errs...)
+}
+
+// WithMetrics makes the WordLens report to m.
+func WithMetrics(m *Metrics) Option {
+	return func(wl *WordLens) {
+		wl.metrics = m
+	}
+}
+
+// hooks returns the strategy hooks that feed m for tech. It is safe to
+// call on a nil *Metrics.
+func (m *Metrics) hooks(tech Technique) *Hooks {
+	if m == nil {
+		return nil
+	}
+
+	goroutines := m.goroutines.WithLabelValues(string(tech))
+	queueDepth := m.queueDepth.WithLabelValues(string(tech))
+	return &Hooks{
+		OnSpawn: func(n int) { goroutines.Add(float64(n)) },
+		OnQueue: func(depth int) { queueDepth.Set(float64(depth)) },
+	}
+}
+
+// observe records a finished run of tech. Runs that were cut short are not
+// recorded, since a strategy does not report how far it got.
+func (m *Metrics) observe(tech Technique, words int, counts map[string]int, elapsed time.Duration, err error) {
+	if m == nil || err != nil {
+		return
+	}
+
+	label := string(tech)
+	m.duration.WithLabelValues(label).Observe(elapsed.Seconds())
+	m.words.WithLabelValues(label).Add(float64(words))
+
+	matches := 0
+	for _, n := range counts {
+		matches += n
+	}
+	m.matches.WithLabelValues(label).Add(float64(matches))
+}
@@ -0,0 +1,144 @@
+package e2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := e2.NewMetrics(reg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	words := wordlens.TestWords()
+	wl := e2.NewWordLens(e2.WithMetrics(m), e2.WithWorkers(4))
+	res := wl.FindPalindromes(words, true, e2.TechniqueWorkers)
+	wl.FindPalindromes(words, true, e2.TechniqueMutex)
+
+	found := 0
+	for _, n := range res {
+		found += n
+	}
+
+	tests := map[string]struct {
+		metric    string
+		technique string
+		expected  float64
+	}{
+		"words processed": {
+			metric:    "wordlens_words_processed_total",
+			technique: "workers",
+			expected:  float64(len(words)),
+		},
+		"palindromes found": {
+			metric:    "wordlens_palindromes_found_total",
+			technique: "workers",
+			expected:  float64(found),
+		},
+		"worker goroutines": {
+			metric:    "wordlens_goroutines_spawned_total",
+			technique: "workers",
+			expected:  5,
+		},
+		"goroutine per word": {
+			metric:    "wordlens_goroutines_spawned_total",
+			technique: "mutex",
+			expected:  float64(len(words)),
+		},
+		"queue drained": {
+			metric:    "wordlens_worker_queue_depth",
+			technique: "workers",
+			expected:  0,
+		},
+		"latency observed": {
+			metric:    "wordlens_technique_duration_seconds",
+			technique: "mutex",
+			expected:  1,
+		},
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error gathering, got %v", err)
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := metricValue(families, tc.metric, tc.technique)
+			if !ok {
+				t.Fatalf("Expected %s{technique=%q} to be reported", tc.metric, tc.technique)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewMetricsRegisteredTwice(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := e2.NewMetrics(reg); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := e2.NewMetrics(reg); err == nil {
+		t.Errorf("Expected an error registering the collectors twice")
+	}
+}
+
+// metricValue returns the value of the metric with the given technique
+// label; for histograms it is the number of observations.
+func metricValue(families []*dto.MetricFamily, name, technique string) (float64, bool) {
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() != "technique" || l.GetValue() != technique {
+					continue
+				}
+				switch {
+				case m.Counter != nil:
+					return m.GetCounter().GetValue(), true
+				case m.Gauge != nil:
+					return m.GetGauge().GetValue(), true
+				case m.Histogram != nil:
+					return float64(m.GetHistogram().GetSampleCount()), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestMetricsCanceled(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := e2.NewMetrics(reg)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	wl := e2.NewWordLens(e2.WithMetrics(m), e2.WithWorkers(4))
+	if _, err := wl.FindPalindromesContext(ctx, wordlens.TestWords(), e2.TechniqueWorkers); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Expected no error gathering, got %v", err)
+	}
+	for _, metric := range []string{"wordlens_words_processed_total", "wordlens_palindromes_found_total", "wordlens_technique_duration_seconds"} {
+		if got, ok := metricValue(families, metric, "workers"); ok && got != 0 {
+			t.Errorf("Expected no %s for a canceled run, got %v", metric, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package e2
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Occurrences is how often a word was found and where. Positions are in
+// the order they appear in the text and may be capped, while Count is
+// always the full count.
+type Occurrences struct {
+	Count     int        `json:"count"`
+	Positions []Position `json:"positions"`
+}
+
+// FindPalindromeOccurrences is like FindPalindromesReader but also records
+// the position of every palindrome, keeping at most maxPositions per word,
+// or all of them when maxPositions is 0.
+func (wl *WordLens) FindPalindromeOccurrences(ctx context.Context, rdr io.Reader, tech Technique, maxPositions int) (map[string]*Occurrences, error) {
+	occs := make(map[string]*Occurrences)
+
+	// techniques only report which words matched, so the positions are
+	// picked up from the batch afterwards; a word matches wherever it is
+	fold := func(b batch, res map[string]int, err error) {
+		if err != nil {
+			// a partial count can't tell which of a word's tokens were
+			// checked, so the batch is left out entirely
+			return
+		}
+		for i, word := range b.words {
+			if _, ok := res[word]; !ok {
+				continue
+			}
+			occ := occs[word]
+			if occ == nil {
+				occ = &Occurrences{}
+				occs[word] = occ
+			}
+			occ.Count++
+			if maxPositions <= 0 || len(occ.Positions) < maxPositions {
+				occ.Positions = append(occ.Positions, b.positions[i])
+			}
+		}
+	}
+
+	err := wl.foldReader(ctx, "WordLens.FindPalindromeOccurrences", rdr, tech, wl.isPalindrome, true, fold,
+		attribute.Int("maxPositions", maxPositions))
+	return occs, err
+}
@@ -0,0 +1,119 @@
+package e2_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestFindPalindromeOccurrences(t *testing.T) {
+	text := "noon level\n  did noon\nhello"
+
+	tests := map[string]struct {
+		maxPositions int
+		expected     map[string]*e2.Occurrences
+	}{
+		"all positions": {
+			maxPositions: 0,
+			expected: map[string]*e2.Occurrences{
+				"noon":  {Count: 2, Positions: []e2.Position{{Offset: 0, Line: 1, Column: 1}, {Offset: 17, Line: 2, Column: 7}}},
+				"level": {Count: 1, Positions: []e2.Position{{Offset: 5, Line: 1, Column: 6}}},
+				"did":   {Count: 1, Positions: []e2.Position{{Offset: 13, Line: 2, Column: 3}}},
+			},
+		},
+		"capped": {
+			maxPositions: 1,
+			expected: map[string]*e2.Occurrences{
+				"noon":  {Count: 2, Positions: []e2.Position{{Offset: 0, Line: 1, Column: 1}}},
+				"level": {Count: 1, Positions: []e2.Position{{Offset: 5, Line: 1, Column: 6}}},
+				"did":   {Count: 1, Positions: []e2.Position{{Offset: 13, Line: 2, Column: 3}}},
+			},
+		},
+	}
+
+	wl := e2.NewWordLens()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, tech := range e2.Techniques() {
+				got, err := wl.FindPalindromeOccurrences(context.Background(), strings.NewReader(text), tech, tc.maxPositions)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if !reflect.DeepEqual(got, tc.expected) {
+					t.Errorf("%s: Expected %v, got %v", tech, tc.expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFindPalindromeOccurrencesLongWord(t *testing.T) {
+	long := strings.Repeat("a", 70_000)
+	wl := e2.NewWordLens()
+	got, err := wl.FindPalindromeOccurrences(context.Background(), strings.NewReader(long+"\nnoon"), e2.TechniqueSequential, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := map[string]*e2.Occurrences{
+		long:   {Count: 1, Positions: []e2.Position{{Offset: 0, Line: 1, Column: 1}}},
+		"noon": {Count: 1, Positions: []e2.Position{{Offset: 70_001, Line: 2, Column: 1}}},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %d palindromes, got %d", len(expected), len(got))
+	}
+}
+
+func TestFindPalindromeOccurrencesBook(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	wl := e2.NewWordLens()
+	counts, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(string(book)), e2.TechniqueSequential)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	occs, err := wl.FindPalindromeOccurrences(context.Background(), strings.NewReader(string(book)), e2.TechniqueLocal, 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(occs) != len(counts) {
+		t.Fatalf("Expected %d words, got %d", len(counts), len(occs))
+	}
+	for word, n := range counts {
+		occ := occs[word]
+		if occ == nil || occ.Count != n {
+			t.Errorf("Expected %s %d times, got %v", word, n, occ)
+			continue
+		}
+		for _, pos := range occ.Positions {
+			if got := string(book[pos.Offset : pos.Offset+len(word)]); got != word {
+				t.Errorf("Expected %q at offset %d, got %q", word, pos.Offset, got)
+			}
+		}
+	}
+
+	if occ := occs["tenet"]; occ == nil || occ.Positions[0].Line != 1268 {
+		t.Errorf("Expected tenet on line 1268, got %v", occ)
+	}
+}
+
+func TestOccurrencesJSON(t *testing.T) {
+	occ := e2.Occurrences{Count: 2, Positions: []e2.Position{{Offset: 17, Line: 2, Column: 7}}}
+	b, err := json.Marshal(occ)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := `{"count":2,"positions":[{"offset":17,"line":2,"column":7}]}`
+	if string(b) != expected {
+		t.Errorf("Expected %s, got %s", expected, b)
+	}
+}
@@ -0,0 +1,120 @@
+package e2
+
+import (
+	"context"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+)
+
+// PhraseOptions bounds the phrases reported by FindPhrasePalindromes.
+// Lengths are counted in runes of the normalized phrase; a zero MaxLength
+// means no upper bound.
+type PhraseOptions struct {
+	MinWords  int
+	MaxWords  int
+	MinLength int
+	MaxLength int
+}
+
+var DefaultPhraseOptions = PhraseOptions{
+	MinWords:  2,
+	MaxWords:  8,
+	MinLength: 7,
+}
+
+// Phrase is a run of consecutive words that reads the same in both
+// directions once normalized. Start is the position of the first word and
+// End the position just past the last one.
+type Phrase struct {
+	Text       string   `json:"text"`
+	Normalized string   `json:"normalized"`
+	Words      int      `json:"words"`
+	Start      Position `json:"start"`
+	End        Position `json:"end"`
+}
+
+type phraseToken struct {
+	token
+	normalized string
+}
+
+// FindPhrasePalindromes slides a window of opts.MinWords to opts.MaxWords
+// words over rdr and reports every window that is a palindrome, such as
+// "A man, a plan, a canal: Panama!". Words are normalized with the
+// WordLens normalizer, or normalize.All if none was configured; words that
+// normalize to nothing are skipped.
+func (wl *WordLens) FindPhrasePalindromes(ctx context.Context, rdr io.Reader, opts PhraseOptions) ([]Phrase, error) {
+	if opts.MinWords < 1 {
+		opts.MinWords = 1
+	}
+	if opts.MaxWords < opts.MinWords {
+		opts.MaxWords = opts.MinWords
+	}
+
+	var normalizer normalize.Normalizer = normalize.All
+	if wl.normalizer != nil {
+		normalizer = wl.normalizer
+	}
+
+	var phrases []Phrase
+	var window []phraseToken
+	var sb strings.Builder
+
+	scanner := newTokenScanner(rdr, wl.tokens)
+	batchSize := wl.batchLen()
+	for i := 0; scanner.Scan(); i++ {
+		if i%batchSize == 0 {
+			if err := ctx.Err(); err != nil {
+				return phrases, err
+			}
+		}
+
+		tok := scanner.Token()
+		normalized := normalizer.Normalize(tok.text)
+		if normalized == "" {
+			continue
+		}
+
+		window = append(window, phraseToken{token: tok, normalized: normalized})
+		if len(window) > opts.MaxWords {
+			window = window[1:]
+		}
+
+		// check every window that ends with the word just read
+		for n := opts.MinWords; n <= len(window); n++ {
+			words := window[len(window)-n:]
+
+			sb.Reset()
+			for _, w := range words {
+				sb.WriteString(w.normalized)
+			}
+			candidate := sb.String()
+
+			length := utf8.RuneCountInString(candidate)
+			if length < opts.MinLength || (opts.MaxLength > 0 && length > opts.MaxLength) {
+				continue
+			}
+			if !palindrome.Runes(candidate) {
+				continue
+			}
+
+			text := make([]string, len(words))
+			for j, w := range words {
+				text[j] = w.text
+			}
+			phrases = append(phrases, Phrase{
+				Text:       strings.Join(text, " "),
+				Normalized: candidate,
+				Words:      n,
+				Start:      words[0].pos,
+				End:        words[n-1].end,
+			})
+		}
+	}
+
+	return phrases, scanner.Err()
+}
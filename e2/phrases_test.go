@@ -0,0 +1,114 @@
+package e2_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestFindPhrasePalindromes(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		opts     e2.PhraseOptions
+		expected []e2.Phrase
+	}{
+		"empty": {
+			text:     "",
+			opts:     e2.DefaultPhraseOptions,
+			expected: nil,
+		},
+		"across lines": {
+			text: "He said: A man, a plan,\n  a canal: Panama! and left",
+			opts: e2.DefaultPhraseOptions,
+			expected: []e2.Phrase{
+				{
+					Text:       "A man, a plan, a canal: Panama!",
+					Normalized: "amanaplanacanalpanama",
+					Words:      7,
+					Start:      e2.Position{Offset: 9, Line: 1, Column: 10},
+					End:        e2.Position{Offset: 42, Line: 2, Column: 19},
+				},
+			},
+		},
+		"too many words": {
+			text:     "A man, a plan, a canal: Panama!",
+			opts:     e2.PhraseOptions{MinWords: 2, MaxWords: 6, MinLength: 7},
+			expected: nil,
+		},
+		"too long": {
+			text:     "A man, a plan, a canal: Panama!",
+			opts:     e2.PhraseOptions{MinWords: 2, MaxWords: 8, MinLength: 7, MaxLength: 20},
+			expected: nil,
+		},
+		"skips punctuation": {
+			text: "Step on -- no pets",
+			opts: e2.DefaultPhraseOptions,
+			expected: []e2.Phrase{
+				{
+					Text:       "Step on no pets",
+					Normalized: "steponnopets",
+					Words:      4,
+					Start:      e2.Position{Offset: 0, Line: 1, Column: 1},
+					End:        e2.Position{Offset: 18, Line: 1, Column: 19},
+				},
+			},
+		},
+	}
+
+	wl := e2.NewWordLens()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := wl.FindPhrasePalindromes(context.Background(), strings.NewReader(tc.text), tc.opts)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(res) != len(tc.expected) {
+				t.Fatalf("Expected %d phrases, got %d: %+v", len(tc.expected), len(res), res)
+			}
+			for i := range res {
+				if res[i] != tc.expected[i] {
+					t.Errorf("Expected phrase %d to be %+v, got %+v", i, tc.expected[i], res[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFindPhrasePalindromesZeroValue(t *testing.T) {
+	var wl e2.WordLens
+	res, err := wl.FindPhrasePalindromes(context.Background(), strings.NewReader("step on no pets"), e2.PhraseOptions{MinWords: 4, MaxWords: 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(res) != 1 || res[0].Normalized != "steponnopets" {
+		t.Errorf("Expected %q, got %+v", "steponnopets", res)
+	}
+}
+
+func TestFindPhrasePalindromesBook(t *testing.T) {
+	f, err := os.Open("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to open book: %v", err)
+	}
+	defer f.Close()
+
+	wl := e2.NewWordLens()
+	res, err := wl.FindPhrasePalindromes(context.Background(), f, e2.DefaultPhraseOptions)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found := false
+	for _, p := range res {
+		if p.Normalized == "noriron" && p.Start.Line == 3790 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected to find %q on line 3790, got %+v", "nor iron", res)
+	}
+}
@@ -0,0 +1,167 @@
+package e2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ReportOptions control how Report divides a text into sections. A line
+// matching Heading, without its line ending, starts a section titled by
+// it. A line matching Start drops everything read before it and a line
+// matching End stops the report, which leaves out boilerplate around the
+// text such as Project Gutenberg's. Top is the number of most frequent
+// palindromes listed per section, 0 for all.
+type ReportOptions struct {
+	Heading *regexp.Regexp
+	Start   *regexp.Regexp
+	End     *regexp.Regexp
+	Top     int
+}
+
+// DefaultReportOptions take every line written in capitals only, starting
+// at the first column, as a heading and know Project Gutenberg's markers.
+var DefaultReportOptions = ReportOptions{
+	Heading: regexp.MustCompile(`^\p{Lu}[\p{Lu} ]*\p{Lu}$`),
+	Start:   regexp.MustCompile(`^\*\*\* ?START OF `),
+	End:     regexp.MustCompile(`^\*\*\* ?END OF `),
+	Top:     5,
+}
+
+// Section holds the palindrome statistics of a part of a text. Line is the
+// number of its heading's line, counting from 1, or of its first line when
+// it has no title. Density is the number of palindromes
+// per 1,000 words and Lengths[i] the number of palindromes of i+1 runes,
+// after normalization.
+type Section struct {
+	Title       string      `json:"title"`
+	Line        int         `json:"line"`
+	Words       int         `json:"words"`
+	Palindromes int         `json:"palindromes"`
+	Distinct    int         `json:"distinct"`
+	Density     float64     `json:"density"`
+	Lengths     []int       `json:"lengths"`
+	Top         []WordCount `json:"top"`
+	Counts      Counts      `json:"-"`
+}
+
+// Report holds the statistics of every section of a text, in order, and
+// of the whole text.
+type Report struct {
+	Sections []Section `json:"sections"`
+	Total    Section   `json:"total"`
+}
+
+// Report reads rdr line by line, divides it into sections at the headings
+// described by opts and runs tech over the words of every section, tokenized
+// with the WordLens tokenizer. Text before the first heading makes a section
+// without a title if it has any words. Every section is held in memory while
+// it is counted. On error the report holds the sections counted so far.
+func (wl *WordLens) Report(ctx context.Context, rdr io.Reader, tech Technique, opts ReportOptions) (report *Report, err error) {
+	ctx, span := wl.startSpan(ctx, "WordLens.Report", tech, attribute.String("tokenizer", wl.tokens.String()))
+	defer span.End()
+	defer func() {
+		endSpan(span, report.Total.Counts, err)
+		span.SetAttributes(attribute.Int("numSections", len(report.Sections)))
+	}()
+
+	report = &Report{}
+	var (
+		title string
+		start = 1
+		text  bytes.Buffer
+	)
+	flush := func() error {
+		s, err := wl.section(ctx, title, start, text.String(), tech, opts.Top)
+		if s.Title != "" || s.Words > 0 {
+			report.Sections = append(report.Sections, s)
+		}
+		return err
+	}
+
+	br := bufio.NewReader(rdr)
+	for n := 1; ; n++ {
+		line, readErr := br.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			err = readErr
+			break
+		}
+
+		bare := strings.TrimRight(line, " \t\r\n")
+		switch {
+		case line == "":
+		case opts.End != nil && opts.End.MatchString(bare):
+			readErr = io.EOF
+		case opts.Start != nil && opts.Start.MatchString(bare):
+			report.Sections = nil
+			title, start = "", n+1
+			text.Reset()
+		case opts.Heading != nil && opts.Heading.MatchString(bare):
+			err = flush()
+			title, start = bare, n
+			text.Reset()
+		default:
+			text.WriteString(line)
+		}
+		if err != nil {
+			break
+		}
+		if readErr == io.EOF {
+			err = flush()
+			break
+		}
+	}
+
+	report.Total = wl.total(report.Sections, opts.Top)
+	return report, err
+}
+
+// section counts the palindromes in text.
+func (wl *WordLens) section(ctx context.Context, title string, line int, text string, tech Technique, top int) (Section, error) {
+	words := wl.tokens.Split(text)
+	counts, err := wl.FindPalindromesContext(ctx, words, tech)
+	s := Section{Title: title, Line: line, Words: len(words), Counts: counts}
+	wl.summarize(&s, top)
+	return s, err
+}
+
+// total sums up sections into a section for the whole text.
+func (wl *WordLens) total(sections []Section, top int) Section {
+	s := Section{Title: "Total", Line: 1, Counts: make(Counts)}
+	if len(sections) > 0 {
+		s.Line = sections[0].Line
+	}
+	for _, sec := range sections {
+		s.Words += sec.Words
+		s.Counts.Merge(sec.Counts)
+	}
+	wl.summarize(&s, top)
+	return s
+}
+
+// summarize fills in the statistics of s derived from its words and counts.
+func (wl *WordLens) summarize(s *Section, top int) {
+	s.Palindromes = s.Counts.Total()
+	s.Distinct = len(s.Counts)
+	if s.Words > 0 {
+		s.Density = float64(s.Palindromes) * 1000 / float64(s.Words)
+	}
+	s.Lengths = []int{}
+	for word, n := range s.Counts {
+		if wl.normalizer != nil {
+			word = wl.normalizer.Normalize(word)
+		}
+		l := utf8.RuneCountInString(word)
+		for len(s.Lengths) < l {
+			s.Lengths = append(s.Lengths, 0)
+		}
+		s.Lengths[l-1] += n
+	}
+	s.Top = s.Counts.TopN(top)
+}
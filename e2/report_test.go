@@ -0,0 +1,145 @@
+package e2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+func TestReport(t *testing.T) {
+	type section struct {
+		title       string
+		line        int
+		words       int
+		palindromes int
+		lengths     []int
+	}
+
+	tests := map[string]struct {
+		text     string
+		opts     []e2.Option
+		expected []section
+	}{
+		"empty": {
+			text:     "",
+			expected: nil,
+		},
+		"headings": {
+			text: "noon and\nTHE FIRST BOOK\nnoon level hello\n\nTHE SECOND BOOK\r\n",
+			expected: []section{
+				{"", 1, 2, 1, []int{0, 0, 0, 1}},
+				{"THE FIRST BOOK", 2, 3, 2, []int{0, 0, 0, 1, 1}},
+				{"THE SECOND BOOK", 5, 0, 0, []int{}},
+			},
+		},
+		"no heading": {
+			text: "  NOT A HEADING\nNot A Heading\nwow",
+			expected: []section{
+				{"", 1, 7, 3, []int{2, 0, 1}},
+			},
+		},
+		"gutenberg markers": {
+			text: "noon\r\n*** START OF THE BOOK ***\r\nnoon\r\nNOTES\r\nwow wow\r\n*** END OF THE BOOK ***\r\nLICENSE\r\nnoon\r\n",
+			expected: []section{
+				{"", 3, 1, 1, []int{0, 0, 0, 1}},
+				{"NOTES", 4, 2, 2, []int{0, 0, 2}},
+			},
+		},
+		// lengths are of the normalized words
+		"normalized": {
+			text: "GLOSSARY\nNoon, a A\n",
+			opts: []e2.Option{e2.WithNormalizer(normalize.All), e2.WithTokenizer(tokenize.None)},
+			expected: []section{
+				{"GLOSSARY", 1, 3, 3, []int{2, 0, 0, 1}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wl := e2.NewWordLens(tc.opts...)
+			report, err := wl.Report(context.Background(), strings.NewReader(tc.text), e2.TechniqueSequential, e2.DefaultReportOptions)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			var got []section
+			var words, palindromes int
+			for _, s := range report.Sections {
+				got = append(got, section{s.Title, s.Line, s.Words, s.Palindromes, s.Lengths})
+				words += s.Words
+				palindromes += s.Palindromes
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+			if report.Total.Words != words || report.Total.Palindromes != palindromes {
+				t.Errorf("Expected a total of %d words and %d palindromes, got %d and %d", words, palindromes, report.Total.Words, report.Total.Palindromes)
+			}
+		})
+	}
+}
+
+func TestReportBook(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to read book: %v", err)
+	}
+
+	wl := e2.NewWordLens(e2.WithNormalizer(normalize.All), e2.WithTokenizer(tokenize.All))
+	report, err := wl.Report(context.Background(), strings.NewReader(string(book)), e2.TechniqueSequential, e2.DefaultReportOptions)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var books []string
+	for _, s := range report.Sections {
+		if strings.HasSuffix(s.Title, " BOOK") && strings.HasPrefix(s.Title, "THE ") {
+			books = append(books, s.Title)
+		}
+		if len(s.Top) > e2.DefaultReportOptions.Top {
+			t.Errorf("Expected at most %d top palindromes in %q, got %d", e2.DefaultReportOptions.Top, s.Title, len(s.Top))
+		}
+		if s.Words > 0 && s.Density != float64(s.Palindromes)*1000/float64(s.Words) {
+			t.Errorf("Expected density of %q to be per 1,000 words, got %f", s.Title, s.Density)
+		}
+	}
+	if len(books) != 12 || books[0] != "THE FIRST BOOK" || books[11] != "THE TWELFTH BOOK" {
+		t.Errorf("Expected the twelve books, got %v", books)
+	}
+	if first, last := report.Sections[0].Title, report.Sections[len(report.Sections)-1].Title; first != "MEDITATIONS" || last != "GLOSSARY" {
+		t.Errorf("Expected sections from MEDITATIONS to GLOSSARY, got %q to %q", first, last)
+	}
+
+	// without the markers Project Gutenberg's header and license are counted
+	opts := e2.DefaultReportOptions
+	opts.Start, opts.End = nil, nil
+	all, err := wl.Report(context.Background(), strings.NewReader(string(book)), e2.TechniqueSequential, opts)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if all.Total.Words <= report.Total.Words {
+		t.Errorf("Expected more than %d words with the boilerplate, got %d", report.Total.Words, all.Total.Words)
+	}
+}
+
+func TestReportCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wl := e2.NewWordLens()
+	report, err := wl.Report(ctx, strings.NewReader("NOTES\nnoon level\n"), e2.TechniqueSequential, e2.DefaultReportOptions)
+	if !errors.Is(err, e2.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected incomplete and canceled error, got %v", err)
+	}
+	if report == nil {
+		t.Fatal("Expected a partial report")
+	}
+}
@@ -0,0 +1,86 @@
+package e2
+
+import (
+	"sort"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+)
+
+// Semordnilap is a pair of different words that are each other's reversal,
+// such as "stressed" and "desserts", with how often each occurred. Word
+// sorts before Reversal.
+type Semordnilap struct {
+	Word          string `json:"word"`
+	Reversal      string `json:"reversal"`
+	WordCount     int    `json:"wordCount"`
+	ReversalCount int    `json:"reversalCount"`
+}
+
+// SemordnilapLens finds semordnilaps across the whole input. Unlike a
+// palindrome, whether a word qualifies depends on the rest of the corpus, so
+// Analyze only discards palindromes and Aggregate matches the remaining
+// words against an index of everything that was counted. Words are compared
+// after applying Normalizer, which defaults to folding case and stripping
+// punctuation.
+type SemordnilapLens struct {
+	Normalizer normalize.Normalizer
+}
+
+func (l SemordnilapLens) normalize(word string) string {
+	if l.Normalizer == nil {
+		return (normalize.FoldCase | normalize.StripPunctuation).Normalize(word)
+	}
+	return l.Normalizer.Normalize(word)
+}
+
+func (l SemordnilapLens) Analyze(word string) bool {
+	word = l.normalize(word)
+	return word != "" && !palindrome.Runes(word)
+}
+
+// Aggregate returns the pairs ordered by their combined count, largest
+// first, then by word.
+func (l SemordnilapLens) Aggregate(counts map[string]int) []Semordnilap {
+	index := make(map[string]int, len(counts))
+	for word, n := range counts {
+		index[l.normalize(word)] += n
+	}
+
+	var pairs []Semordnilap
+	for word, n := range index {
+		reversal := reverse(word)
+		if m, ok := index[reversal]; ok && word < reversal {
+			pairs = append(pairs, Semordnilap{
+				Word:          word,
+				Reversal:      reversal,
+				WordCount:     n,
+				ReversalCount: m,
+			})
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		ti := pairs[i].WordCount + pairs[i].ReversalCount
+		tj := pairs[j].WordCount + pairs[j].ReversalCount
+		if ti != tj {
+			return ti > tj
+		}
+		return pairs[i].Word < pairs[j].Word
+	})
+	return pairs
+}
+
+// FindSemordnilaps returns the semordnilap pairs in words, using the
+// WordLens' normalizer if it has one.
+func (wl *WordLens) FindSemordnilaps(words []string, tech Technique) []Semordnilap {
+	return Analyze(wl, words, tech, SemordnilapLens{Normalizer: wl.normalizer})
+}
+
+func reverse(word string) string {
+	runes := []rune(word)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
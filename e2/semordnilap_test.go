@@ -0,0 +1,71 @@
+package e2_test
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestFindSemordnilaps(t *testing.T) {
+	tests := map[string]struct {
+		words    []string
+		expected []e2.Semordnilap
+	}{
+		"empty": {
+			words:    []string{},
+			expected: nil,
+		},
+		"pairs": {
+			words: []string{"stressed", "Desserts.", "desserts", "rotator", "live", "evil", "evil", "hello", "no", "On"},
+			expected: []e2.Semordnilap{
+				{Word: "desserts", Reversal: "stressed", WordCount: 2, ReversalCount: 1},
+				{Word: "evil", Reversal: "live", WordCount: 2, ReversalCount: 1},
+				{Word: "no", Reversal: "on", WordCount: 1, ReversalCount: 1},
+			},
+		},
+		"palindromes excluded": {
+			words:    []string{"rotator", "rotator", "level"},
+			expected: nil,
+		},
+	}
+
+	wl := e2.NewWordLens()
+
+	for name, tc := range tests {
+		for _, tech := range e2.Techniques() {
+			t.Run(name+"/"+string(tech), func(t *testing.T) {
+				res := wl.FindSemordnilaps(tc.words, tech)
+				if !reflect.DeepEqual(res, tc.expected) {
+					t.Errorf("Expected %+v, got %+v", tc.expected, res)
+				}
+			})
+		}
+	}
+}
+
+func TestFindSemordnilapsBook(t *testing.T) {
+	f, err := os.Open("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to open book: %v", err)
+	}
+	defer f.Close()
+
+	wl := e2.NewWordLens()
+	res, err := e2.AnalyzeReader(context.Background(), &wl, f, e2.TechniqueWorkers, e2.SemordnilapLens{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	found := false
+	for _, p := range res {
+		if p.Word == "dog" && p.Reversal == "god" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected to find dog/god in the book, got %+v", res)
+	}
+}
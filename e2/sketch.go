@@ -0,0 +1,252 @@
+package e2
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"math"
+	"slices"
+)
+
+// CountMinSketch estimates how often each word was added using a fixed
+// amount of memory. An estimate is never below the true count and, with
+// probability 1-delta, exceeds it by at most epsilon times Total.
+//
+// Words are hashed deterministically, so sketches created with the same
+// epsilon and delta can be merged even when built by different processes.
+type CountMinSketch struct {
+	width  int
+	depth  int
+	counts []uint64
+	total  uint64
+}
+
+func validateBounds(epsilon, delta float64) error {
+	if epsilon <= 0 || epsilon >= 1 {
+		return errors.New("epsilon must be between 0 and 1")
+	}
+	if delta <= 0 || delta >= 1 {
+		return errors.New("delta must be between 0 and 1")
+	}
+	return nil
+}
+
+func NewCountMinSketch(epsilon, delta float64) (*CountMinSketch, error) {
+	if err := validateBounds(epsilon, delta); err != nil {
+		return nil, err
+	}
+	width := int(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	return &CountMinSketch{
+		width:  width,
+		depth:  depth,
+		counts: make([]uint64, width*depth),
+	}, nil
+}
+
+// hash64 is 64-bit FNV-1a, inlined to avoid allocating a hash.Hash per word.
+func hash64(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// cell returns the index of word's counter in row, deriving one hash per
+// row from two halves of a single hash.
+func (s *CountMinSketch) cell(h uint64, row int) int {
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	return row*s.width + int((h1+uint64(row)*h2)%uint64(s.width))
+}
+
+func (s *CountMinSketch) Add(word string, n uint64) {
+	h := hash64(word)
+	for row := 0; row < s.depth; row++ {
+		s.counts[s.cell(h, row)] += n
+	}
+	s.total += n
+}
+
+func (s *CountMinSketch) Estimate(word string) uint64 {
+	h := hash64(word)
+	est := uint64(math.MaxUint64)
+	for row := 0; row < s.depth; row++ {
+		est = min(est, s.counts[s.cell(h, row)])
+	}
+	return est
+}
+
+// Total is the sum of every count added.
+func (s *CountMinSketch) Total() uint64 {
+	return s.total
+}
+
+// Merge adds the counts of o, which must have the same dimensions, to s.
+func (s *CountMinSketch) Merge(o *CountMinSketch) error {
+	if s.width != o.width || s.depth != o.depth {
+		return errors.New("e2: cannot merge sketches of different sizes")
+	}
+	for i, n := range o.counts {
+		s.counts[i] += n
+	}
+	s.total += o.total
+	return nil
+}
+
+// SpaceSaving tracks the k most frequent words with the space-saving
+// algorithm. A word's count never undercounts it and overcounts it by at
+// most its Error, which is itself at most Total/k.
+type SpaceSaving struct {
+	k     int
+	h     counterHeap
+	total uint64
+}
+
+func NewSpaceSaving(k int) (*SpaceSaving, error) {
+	if k <= 0 {
+		return nil, errors.New("k must be positive")
+	}
+	return &SpaceSaving{k: k, h: counterHeap{index: make(map[string]int, k)}}, nil
+}
+
+func (s *SpaceSaving) Add(word string, n uint64) {
+	s.total += n
+	if i, ok := s.h.index[word]; ok {
+		s.h.items[i].count += n
+		heap.Fix(&s.h, i)
+		return
+	}
+	if len(s.h.items) < s.k {
+		heap.Push(&s.h, counter{word: word, count: n})
+		return
+	}
+
+	// the new word takes over the least frequent counter, inheriting its
+	// count as the possible overestimate
+	least := s.h.items[0]
+	delete(s.h.index, least.word)
+	s.h.items[0] = counter{word: word, count: least.count + n, err: least.count}
+	s.h.index[word] = 0
+	heap.Fix(&s.h, 0)
+}
+
+// min is the largest count an untracked word can have.
+func (s *SpaceSaving) min() uint64 {
+	if len(s.h.items) < s.k {
+		return 0
+	}
+	return s.h.items[0].count
+}
+
+// Estimate returns the count and error of word, or the largest possible
+// count of an untracked word with the same value as error.
+func (s *SpaceSaving) Estimate(word string) (count, err uint64) {
+	if i, ok := s.h.index[word]; ok {
+		return s.h.items[i].count, s.h.items[i].err
+	}
+	return s.min(), s.min()
+}
+
+// Total is the sum of every count added.
+func (s *SpaceSaving) Total() uint64 {
+	return s.total
+}
+
+// Merge combines o into s, keeping the k largest counts. A word missing
+// from one summary is assumed to have that summary's minimum count, so the
+// merged counts keep the same guarantees.
+func (s *SpaceSaving) Merge(o *SpaceSaving) {
+	sMin, oMin := s.min(), o.min()
+
+	merged := make(map[string]counter, len(s.h.items)+len(o.h.items))
+	for _, c := range s.h.items {
+		c.count += oMin
+		c.err += oMin
+		merged[c.word] = c
+	}
+	for _, c := range o.h.items {
+		if m, ok := merged[c.word]; ok {
+			m.count += c.count - oMin
+			m.err += c.err - oMin
+			merged[c.word] = m
+			continue
+		}
+		c.count += sMin
+		c.err += sMin
+		merged[c.word] = c
+	}
+
+	items := make([]counter, 0, len(merged))
+	for _, c := range merged {
+		items = append(items, c)
+	}
+	slices.SortFunc(items, counter.compare)
+	if len(items) > s.k {
+		items = items[:s.k]
+	}
+
+	s.h = counterHeap{items: items, index: make(map[string]int, s.k)}
+	for i, c := range items {
+		s.h.index[c.word] = i
+	}
+	heap.Init(&s.h)
+	s.total += o.total
+}
+
+// Top returns the tracked words, most frequent first.
+func (s *SpaceSaving) Top() []HeavyHitter {
+	items := slices.Clone(s.h.items)
+	slices.SortFunc(items, counter.compare)
+
+	top := make([]HeavyHitter, len(items))
+	for i, c := range items {
+		top[i] = HeavyHitter{Word: c.word, Count: c.count, Error: c.err}
+	}
+	return top
+}
+
+type counter struct {
+	word  string
+	count uint64
+	err   uint64
+}
+
+// compare orders counters from the most to the least frequent, breaking
+// ties alphabetically.
+func (c counter) compare(o counter) int {
+	if n := cmp.Compare(o.count, c.count); n != 0 {
+		return n
+	}
+	return cmp.Compare(c.word, o.word)
+}
+
+// counterHeap is a min-heap of counters that keeps track of where each word
+// is so its count can be updated in place.
+type counterHeap struct {
+	items []counter
+	index map[string]int
+}
+
+func (h *counterHeap) Len() int           { return len(h.items) }
+func (h *counterHeap) Less(i, j int) bool { return h.items[i].count < h.items[j].count }
+
+func (h *counterHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].word] = i
+	h.index[h.items[j].word] = j
+}
+
+func (h *counterHeap) Push(x any) {
+	c := x.(counter)
+	h.index[c.word] = len(h.items)
+	h.items = append(h.items, c)
+}
+
+func (h *counterHeap) Pop() any {
+	c := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, c.word)
+	return c
+}
@@ -0,0 +1,181 @@
+package e2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+// zipfStream returns a stream where word i appears about n/(i+1) times,
+// and the exact count of every word.
+func zipfStream(words, n int) ([]string, map[string]int) {
+	var stream []string
+	counts := make(map[string]int)
+	for i := 0; i < words; i++ {
+		word := fmt.Sprintf("w%d", i)
+		for j := 0; j < n/(i+1); j++ {
+			stream = append(stream, word)
+			counts[word]++
+		}
+	}
+	return stream, counts
+}
+
+func TestCountMinSketch(t *testing.T) {
+	stream, counts := zipfStream(1000, 1000)
+	s, err := e2.NewCountMinSketch(0.001, 0.01)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	for _, word := range stream {
+		s.Add(word, 1)
+	}
+
+	if s.Total() != uint64(len(stream)) {
+		t.Errorf("Expected total %d, got %d", len(stream), s.Total())
+	}
+	bound := uint64(0.001 * float64(len(stream)))
+	for word, n := range counts {
+		est := s.Estimate(word)
+		if est < uint64(n) || est > uint64(n)+bound {
+			t.Errorf("Expected %s between %d and %d, got %d", word, n, uint64(n)+bound, est)
+		}
+	}
+}
+
+func TestCountMinSketchMerge(t *testing.T) {
+	a, _ := e2.NewCountMinSketch(0.01, 0.01)
+	b, _ := e2.NewCountMinSketch(0.01, 0.01)
+	a.Add("noon", 2)
+	b.Add("noon", 3)
+	if err := a.Merge(b); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := a.Estimate("noon"); got != 5 {
+		t.Errorf("Expected 5, got %d", got)
+	}
+
+	c, _ := e2.NewCountMinSketch(0.1, 0.01)
+	if err := a.Merge(c); err == nil {
+		t.Error("Expected an error merging sketches of different sizes")
+	}
+}
+
+func TestSpaceSaving(t *testing.T) {
+	stream, counts := zipfStream(1000, 1000)
+	bound := uint64(len(stream) / 50)
+
+	whole, _ := e2.NewSpaceSaving(50)
+	for _, word := range stream {
+		whole.Add(word, 1)
+	}
+
+	// every word is split across both halves so merging has to combine
+	// counts, not just concatenate summaries
+	left, _ := e2.NewSpaceSaving(50)
+	right, _ := e2.NewSpaceSaving(50)
+	for i, word := range stream {
+		if i%2 == 0 {
+			left.Add(word, 1)
+		} else {
+			right.Add(word, 1)
+		}
+	}
+	left.Merge(right)
+
+	tests := map[string]struct {
+		summary *e2.SpaceSaving
+	}{
+		"single": {summary: whole},
+		"merged": {summary: left},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.summary.Total() != uint64(len(stream)) {
+				t.Errorf("Expected total %d, got %d", len(stream), tc.summary.Total())
+			}
+
+			top := tc.summary.Top()
+			if len(top) != 50 {
+				t.Fatalf("Expected 50 words, got %d", len(top))
+			}
+			if top[0].Word != "w0" {
+				t.Errorf("Expected w0 first, got %s", top[0].Word)
+			}
+			for _, h := range top {
+				n := uint64(counts[h.Word])
+				if h.Count < n || h.Count-h.Error > n {
+					t.Errorf("Expected %s count %d error %d to cover %d", h.Word, h.Count, h.Error, n)
+				}
+				if h.Error > bound {
+					t.Errorf("Expected %s error at most %d, got %d", h.Word, bound, h.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHeavyHitters(t *testing.T) {
+	tests := map[string]struct {
+		opts    e2.ApproxOptions
+		wantErr bool
+	}{
+		"defaults":     {opts: e2.DefaultApproxOptions},
+		"no k":         {opts: e2.ApproxOptions{K: 0, Epsilon: 0.01, Delta: 0.01}, wantErr: true},
+		"epsilon zero": {opts: e2.ApproxOptions{K: 10, Epsilon: 0, Delta: 0.01}, wantErr: true},
+		"delta one":    {opts: e2.ApproxOptions{K: 10, Epsilon: 0.01, Delta: 1}, wantErr: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := e2.NewHeavyHitters(tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewHeavyHitters(%+v) error = %v, wantErr %v", tt.opts, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindPalindromesApprox(t *testing.T) {
+	f, err := os.Open("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer f.Close()
+
+	wl := e2.NewWordLens()
+	exact, err := wl.FindPalindromesReader(context.Background(), f, e2.TechniqueSequential)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, tech := range e2.Techniques() {
+		t.Run(string(tech), func(t *testing.T) {
+			if _, err := f.Seek(0, 0); err != nil {
+				t.Fatal(err)
+			}
+			hh, err := wl.FindPalindromesApprox(context.Background(), f, tech, e2.ApproxOptions{K: 10, Epsilon: 0.001, Delta: 0.01})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			top := hh.Top(5)
+			if len(top) != 5 {
+				t.Fatalf("Expected 5 heavy hitters, got %d", len(top))
+			}
+			for _, h := range top {
+				n := uint64(exact[h.Word])
+				if h.Count < n || h.Count-h.Error > n {
+					t.Errorf("Expected %s count %d error %d to cover %d", h.Word, h.Count, h.Error, n)
+				}
+			}
+			if top[0].Word != "a" || top[0].Count != uint64(exact["a"]) {
+				t.Errorf("Expected a with %d, got %s with %d", exact["a"], top[0].Word, top[0].Count)
+			}
+		})
+	}
+}
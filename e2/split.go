@@ -0,0 +1,144 @@
+package e2
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MinRangeSize is the smallest byte range FindPalindromesReaderAt hands to
+// a goroutine, below which splitting costs more than it saves.
+const MinRangeSize = 64 << 10
+
+// FindPalindromesFile is FindPalindromesReaderAt over the file at path.
+func (wl *WordLens) FindPalindromesFile(ctx context.Context, path string, tech Technique) (map[string]int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return wl.FindPalindromesReaderAt(ctx, f, info.Size(), tech)
+}
+
+// FindPalindromesReaderAt counts the palindromes in the first size bytes
+// of r like FindPalindromesReader, but splits them into up to one range per
+// worker, cut between words, and reads, tokenizes and counts every range on
+// its own goroutine before merging the counts. Since the ranges already run
+// in parallel, tech is only used when there is a single range and every
+// range is otherwise counted with TechniqueSequential. The counts of ranges
+// that failed are still merged, and their errors joined.
+func (wl *WordLens) FindPalindromesReaderAt(ctx context.Context, r io.ReaderAt, size int64, tech Technique) (counts map[string]int, err error) {
+	ctx, span := wl.startSpan(ctx, "WordLens.FindPalindromesReaderAt", tech, attribute.Int64("size", size))
+	defer span.End()
+	defer func() { endSpan(span, counts, err) }()
+
+	bounds, err := wl.splitRanges(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(bounds) > 2 {
+		tech = TechniqueSequential
+	}
+	span.SetAttributes(
+		attribute.Int("numRanges", len(bounds)-1),
+		attribute.String("rangeTechnique", string(tech)),
+	)
+
+	results := make([]map[string]int, len(bounds)-1)
+	errs := make([]error, len(bounds)-1)
+	var wg sync.WaitGroup
+	for i := 0; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			section := io.NewSectionReader(r, bounds[i], bounds[i+1]-bounds[i])
+			results[i], errs[i] = wl.countReader(ctx, "WordLens.range", section, tech, wl.isPalindrome)
+		}(i)
+	}
+	wg.Wait()
+
+	counts = make(map[string]int)
+	for _, res := range results {
+		for word, n := range res {
+			counts[word] += n
+		}
+	}
+	return counts, errors.Join(errs...)
+}
+
+// splitRanges divides the first size bytes of r into ranges of about the
+// same size, one per worker but none smaller than MinRangeSize. It returns
+// the offsets between ranges, starting with 0 and ending with size. Every
+// offset but those two is the start of a word that no tokenizer rule would
+// join with the word before it.
+func (wl *WordLens) splitRanges(r io.ReaderAt, size int64) ([]int64, error) {
+	n := int64(wl.workers)
+	n = max(min(n, size/MinRangeSize), 1)
+
+	bounds := []int64{0}
+	for i := int64(1); i < n; i++ {
+		off := max(size*i/n, bounds[len(bounds)-1])
+		b, err := wl.wordBoundary(r, off, size)
+		if err != nil {
+			return nil, err
+		}
+		if b == size {
+			break
+		}
+		if b > bounds[len(bounds)-1] {
+			bounds = append(bounds, b)
+		}
+	}
+	return append(bounds, size), nil
+}
+
+// wordBoundary returns the offset of the first word in r after off that
+// follows ASCII white space, which ends a word with every tokenizer rule.
+// With JoinHyphens, a word after a hyphen and white space may continue the
+// one before it, so the white space must follow something else. It returns
+// size if there is no such word.
+func (wl *WordLens) wordBoundary(r io.ReaderAt, off, size int64) (int64, error) {
+	hyphens := wl.tokens&tokenize.JoinHyphens != 0
+	br := bufio.NewReader(io.NewSectionReader(r, off, size-off))
+
+	// the word before the current white space, if it was read from off
+	var last [3]byte
+	word, space := false, false
+	for p := off; ; p++ {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		switch b {
+		case ' ', '\t', '\n', '\v', '\f', '\r':
+			space = true
+			continue
+		}
+		if space && word && !(hyphens && endsWithHyphen(last)) {
+			return p, nil
+		}
+		space, word = false, true
+		last = [3]byte{last[1], last[2], b}
+	}
+}
+
+// endsWithHyphen reports whether the last bytes of a word are a hyphen,
+// either ASCII or U+2010.
+func endsWithHyphen(last [3]byte) bool {
+	return last[2] == '-' || last == [3]byte{0xe2, 0x80, 0x90}
+}
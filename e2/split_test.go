@@ -0,0 +1,187 @@
+package e2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+func TestFindPalindromesReaderAt(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to read book: %v", err)
+	}
+	words, err := wordlens.GenerateCorpus(wordlens.DefaultCorpusOptions)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	tests := map[string]struct {
+		text   string
+		rules  tokenize.Rules
+		ranges int
+	}{
+		"empty": {
+			text:   "",
+			ranges: 1,
+		},
+		"small": {
+			text:   "noon level hello noon",
+			ranges: 1,
+		},
+		"book": {
+			text:   string(book),
+			ranges: 4,
+		},
+		"generated": {
+			text:   string(wordlens.CorpusText(words)),
+			ranges: 4,
+		},
+		// every cut lands near a word broken across lines, which must not be
+		// split between ranges
+		"hyphenated": {
+			text:   strings.Repeat("de-\n  ed refer-\n\nrer - \n", 20_000),
+			rules:  tokenize.All,
+			ranges: 4,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sr := recordSpans(t)
+			wl := e2.NewWordLens(e2.WithWorkers(4), e2.WithTokenizer(tc.rules))
+			expected, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(tc.text), e2.TechniqueSequential)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			got, err := wl.FindPalindromesReaderAt(context.Background(), strings.NewReader(tc.text), int64(len(tc.text)), e2.TechniqueWorkers)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(got) != len(expected) {
+				t.Errorf("Expected %d palindromes, got %d", len(expected), len(got))
+			}
+			for word, n := range expected {
+				if got[word] != n {
+					t.Errorf("Expected %q to be found %d times, got %d", word, n, got[word])
+				}
+			}
+
+			// ranges running in parallel don't start workers of their own
+			technique := e2.TechniqueWorkers
+			if tc.ranges > 1 {
+				technique = e2.TechniqueSequential
+			}
+			var ranges int
+			for _, span := range sr.Ended() {
+				if span.Name() != "WordLens.range" {
+					continue
+				}
+				ranges++
+				if got := spanAttr(span, "technique").AsString(); got != string(technique) {
+					t.Errorf("Expected range technique %q, got %q", technique, got)
+				}
+			}
+			if ranges != tc.ranges {
+				t.Errorf("Expected %d ranges, got %d", tc.ranges, ranges)
+			}
+		})
+	}
+}
+
+// failingReaderAt fails every read of its last bytes, which only the last
+// range reads.
+type failingReaderAt struct {
+	*strings.Reader
+}
+
+var errRead = errors.New("read failed")
+
+func (r failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > r.Size()-100 {
+		return 0, errRead
+	}
+	return r.Reader.ReadAt(p, off)
+}
+
+func TestFindPalindromesReaderAtErrors(t *testing.T) {
+	text := strings.Repeat("noon level ", 4*e2.MinRangeSize/11)
+	wl := e2.NewWordLens(e2.WithWorkers(4))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := wl.FindPalindromesReaderAt(ctx, strings.NewReader(text), int64(len(text)), e2.TechniqueSequential)
+	if !errors.Is(err, e2.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected incomplete and canceled error, got %v", err)
+	}
+
+	// the other ranges are still counted
+	rdr := failingReaderAt{strings.NewReader(text)}
+	counts, err := wl.FindPalindromesReaderAt(context.Background(), rdr, rdr.Size(), e2.TechniqueSequential)
+	if !errors.Is(err, errRead) {
+		t.Errorf("Expected read error, got %v", err)
+	}
+	if counts["noon"] == 0 {
+		t.Errorf("Expected the readable ranges to be counted, got %v", counts)
+	}
+}
+
+func TestFindPalindromesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(path, []byte("noon level hello noon"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	wl := e2.NewWordLens()
+	got, err := wl.FindPalindromesFile(context.Background(), path, e2.TechniqueSequential)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := map[string]int{"noon": 2, "level": 1}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	_, err = wl.FindPalindromesFile(context.Background(), filepath.Join(t.TempDir(), "missing.txt"), e2.TechniqueSequential)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not exist error, got %v", err)
+	}
+}
+
+// BenchmarkFindPalindromesReaderAt compares reading a generated corpus as
+// one stream against splitting it into ranges read in parallel.
+func BenchmarkFindPalindromesReaderAt(b *testing.B) {
+	opts := wordlens.DefaultCorpusOptions
+	opts.Words = *maxWords
+	words, err := wordlens.GenerateCorpus(opts)
+	if err != nil {
+		b.Fatal(err)
+	}
+	text := string(wordlens.CorpusText(words))
+	wl := e2.NewWordLens()
+
+	b.Run("path=reader", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			if _, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(text), e2.TechniqueSequential); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("path=readerAt", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			if _, err := wl.FindPalindromesReaderAt(context.Background(), strings.NewReader(text), int64(len(text)), e2.TechniqueSequential); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,467 @@
+package e2
+
+import (
+	"context"
+	"hash/maphash"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+type Technique string
+
+const (
+	TechniqueSequential Technique = "sequential"
+	TechniqueMutex      Technique = "mutex"
+	TechniqueChannel    Technique = "channel"
+	TechniqueWorkers    Technique = "workers"
+	TechniqueLocal      Technique = "local"
+	TechniqueSharded    Technique = "sharded"
+	TechniqueBatched    Technique = "batched"
+)
+
+// Input is the work handed to a Strategy: count every word in Words for
+// which Match reports true, using up to Workers goroutines.
+type Input struct {
+	Words   []string
+	Match   func(word string) bool
+	Workers int
+	Hooks   *Hooks
+}
+
+// Hooks let a strategy report on its internals, e.g. to Metrics or a
+// tracer. Any field may be nil, and its methods are safe to call on a nil
+// *Hooks.
+type Hooks struct {
+	OnSpawn  func(n int)
+	OnQueue  func(depth int)
+	OnWorker func(ctx context.Context, id, words int) (done func())
+}
+
+// Spawned reports that the strategy started n goroutines.
+func (h *Hooks) Spawned(n int) {
+	if h != nil && h.OnSpawn != nil {
+		h.OnSpawn(n)
+	}
+}
+
+// Queued reports how many items are waiting in the strategy's queue.
+func (h *Hooks) Queued(depth int) {
+	if h != nil && h.OnQueue != nil {
+		h.OnQueue(depth)
+	}
+}
+
+// Worker reports that worker id started on words words, or 0 when it
+// takes them from a shared queue. The returned func must be called once the
+// worker is done.
+func (h *Hooks) Worker(ctx context.Context, id, words int) (done func()) {
+	if h != nil && h.OnWorker != nil {
+		return h.OnWorker(ctx, id, words)
+	}
+	return func() {}
+}
+
+// Strategy is a way of spreading an Input over goroutines. Strategies are
+// registered under a Technique and looked up by WordLens.
+//
+// When ctx is done a strategy must stop every goroutine it started before
+// returning the counts gathered so far together with ctx.Err().
+type Strategy interface {
+	Count(ctx context.Context, in Input) (map[string]int, error)
+}
+
+// StrategyFunc adapts an ordinary function to the Strategy interface.
+type StrategyFunc func(ctx context.Context, in Input) (map[string]int, error)
+
+func (f StrategyFunc) Count(ctx context.Context, in Input) (map[string]int, error) {
+	return f(ctx, in)
+}
+
+var (
+	strategiesMu sync.RWMutex
+	strategies   = map[Technique]Strategy{
+		TechniqueSequential: StrategyFunc(countSequential),
+		TechniqueMutex:      StrategyFunc(countMutex),
+		TechniqueChannel:    StrategyFunc(countChannel),
+		TechniqueWorkers:    StrategyFunc(countWorkers),
+		TechniqueLocal:      StrategyFunc(countLocal),
+		TechniqueSharded:    StrategyFunc(countSharded),
+		TechniqueBatched:    StrategyFunc(countBatched),
+	}
+)
+
+// Register makes s available as tech, replacing any strategy already
+// registered under that name.
+func Register(tech Technique, s Strategy) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	strategies[tech] = s
+}
+
+// Unregister removes the strategy registered as tech, if any. Words counted
+// with tech afterwards fall back to TechniqueSequential.
+func Unregister(tech Technique) {
+	strategiesMu.Lock()
+	defer strategiesMu.Unlock()
+	delete(strategies, tech)
+}
+
+// Techniques returns the names of every registered strategy, sorted.
+func Techniques() []Technique {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	techs := make([]Technique, 0, len(strategies))
+	for tech := range strategies {
+		techs = append(techs, tech)
+	}
+	sort.Slice(techs, func(i, j int) bool { return techs[i] < techs[j] })
+	return techs
+}
+
+// registered reports whether a strategy is registered as tech.
+func registered(tech Technique) bool {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	_, ok := strategies[tech]
+	return ok
+}
+
+// lookup returns the strategy registered as tech, falling back to the
+// sequential one for unknown techniques.
+func lookup(tech Technique) Strategy {
+	strategiesMu.RLock()
+	defer strategiesMu.RUnlock()
+
+	if s, ok := strategies[tech]; ok {
+		return s
+	}
+	return strategies[TechniqueSequential]
+}
+
+// checkEvery is how many words a goroutine handles between checks of its
+// context, which keeps the cost of checking negligible.
+const checkEvery = 256
+
+func countSequential(ctx context.Context, in Input) (map[string]int, error) {
+	counts := make(map[string]int)
+	for i, word := range in.Words {
+		if i%checkEvery == 0 {
+			if err := ctx.Err(); err != nil {
+				return counts, err
+			}
+		}
+		if in.Match(word) {
+			counts[word]++
+		}
+	}
+	return counts, nil
+}
+
+func countMutex(ctx context.Context, in Input) (map[string]int, error) {
+	counts := make(map[string]int)
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	var err error
+	var skipped atomic.Bool
+	for _, word := range in.Words {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		in.Hooks.Spawned(1)
+		go func(word string) {
+			defer wg.Done()
+			// goroutines may only get to run once ctx is already done
+			if ctx.Err() != nil {
+				skipped.Store(true)
+				return
+			}
+			if in.Match(word) {
+				mu.Lock()
+				counts[word]++
+				mu.Unlock()
+			}
+		}(word)
+	}
+
+	wg.Wait()
+	if err == nil && skipped.Load() {
+		err = ctx.Err()
+	}
+	return counts, err
+}
+
+func countChannel(ctx context.Context, in Input) (map[string]int, error) {
+	counts := make(map[string]int)
+	wg := sync.WaitGroup{}
+	results := make(chan string, len(in.Words))
+
+	var err error
+	var skipped atomic.Bool
+	for _, word := range in.Words {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		wg.Add(1)
+		in.Hooks.Spawned(1)
+		go func(word string) {
+			defer wg.Done()
+			if ctx.Err() != nil {
+				skipped.Store(true)
+				return
+			}
+			if in.Match(word) {
+				results <- word
+			}
+		}(word)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	in.Hooks.Spawned(1)
+
+	for word := range results {
+		in.Hooks.Queued(len(results))
+		counts[word]++
+	}
+	in.Hooks.Queued(0)
+	if err == nil && skipped.Load() {
+		err = ctx.Err()
+	}
+	return counts, err
+}
+
+func countWorkers(ctx context.Context, in Input) (map[string]int, error) {
+	counts := make(map[string]int)
+	jobs := make(chan string, len(in.Words))
+	results := make(chan result)
+	wg := sync.WaitGroup{}
+
+	// start workers
+	wg.Add(in.Workers)
+	in.Hooks.Spawned(in.Workers + 1)
+	for w := 0; w < in.Workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			defer in.Hooks.Worker(ctx, w, 0)()
+			worker(ctx, jobs, results, in.Match)
+		}(w)
+	}
+
+	// start jobs
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for _, word := range in.Words {
+			select {
+			case jobs <- word:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// collect results
+	var err error
+	for i := 0; i < len(in.Words); i++ {
+		select {
+		case res := <-results:
+			in.Hooks.Queued(len(jobs))
+			if res.match {
+				counts[res.word]++
+			}
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	// every goroutine has returned once jobs are exhausted or ctx is done,
+	// so nothing can send on results after this
+	wg.Wait()
+	close(results)
+	in.Hooks.Queued(0)
+	return counts, err
+}
+
+// result is a word checked by a worker. Non-matches are sent as well so
+// the collector knows when every word has been checked.
+type result struct {
+	word  string
+	match bool
+}
+
+func worker(ctx context.Context, jobs <-chan string, results chan<- result, match func(string) bool) {
+	for word := range jobs {
+		select {
+		case results <- result{word: word, match: match(word)}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// partition splits words into at most n contiguous parts of similar size.
+func partition(words []string, n int) [][]string {
+	n = max(min(n, len(words)), 1)
+	size := (len(words) + n - 1) / n
+
+	parts := make([][]string, 0, n)
+	for lo := 0; lo < len(words); lo += size {
+		parts = append(parts, words[lo:min(lo+size, len(words))])
+	}
+	return parts
+}
+
+// countLocal gives every worker its own map and merges them at the end, so
+// workers never contend with each other.
+func countLocal(ctx context.Context, in Input) (map[string]int, error) {
+	parts := partition(in.Words, in.Workers)
+	locals := make([]map[string]int, len(parts))
+	errs := make([]error, len(parts))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(parts))
+	in.Hooks.Spawned(len(parts))
+	for i, part := range parts {
+		go func(i int, part []string) {
+			defer wg.Done()
+			defer in.Hooks.Worker(ctx, i, len(part))()
+			locals[i], errs[i] = countSequential(ctx, Input{Words: part, Match: in.Match})
+		}(i, part)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, local := range locals {
+		for word, n := range local {
+			counts[word] += n
+		}
+	}
+	return counts, firstErr(errs)
+}
+
+// firstErr returns the first non-nil error in errs.
+func firstErr(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const shards = 32
+
+// countSharded shares one map split into shards, each guarded by its own
+// lock, so workers only contend when they hit the same shard.
+func countSharded(ctx context.Context, in Input) (map[string]int, error) {
+	var locks [shards]sync.Mutex
+	var maps [shards]map[string]int
+	for i := range maps {
+		maps[i] = make(map[string]int)
+	}
+	seed := maphash.MakeSeed()
+
+	parts := partition(in.Words, in.Workers)
+	errs := make([]error, len(parts))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(parts))
+	in.Hooks.Spawned(len(parts))
+	for p, part := range parts {
+		go func(p int, part []string) {
+			defer wg.Done()
+			defer in.Hooks.Worker(ctx, p, len(part))()
+			for j, word := range part {
+				if j%checkEvery == 0 {
+					if errs[p] = ctx.Err(); errs[p] != nil {
+						return
+					}
+				}
+				if in.Match(word) {
+					i := maphash.String(seed, word) % shards
+					locks[i].Lock()
+					maps[i][word]++
+					locks[i].Unlock()
+				}
+			}
+		}(p, part)
+	}
+	wg.Wait()
+
+	counts := make(map[string]int)
+	for _, m := range maps {
+		for word, n := range m {
+			counts[word] = n
+		}
+	}
+	return counts, firstErr(errs)
+}
+
+const batchedSendSize = 256
+
+// countBatched has every worker send its matches in slices of up to
+// batchedSendSize words instead of one channel send per word.
+func countBatched(ctx context.Context, in Input) (map[string]int, error) {
+	parts := partition(in.Words, in.Workers)
+	results := make(chan []string, len(parts))
+	errs := make([]error, len(parts))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(parts))
+	in.Hooks.Spawned(len(parts) + 1)
+	for p, part := range parts {
+		go func(p int, part []string) {
+			defer wg.Done()
+			defer in.Hooks.Worker(ctx, p, len(part))()
+			batch := make([]string, 0, batchedSendSize)
+			for j, word := range part {
+				if j%checkEvery == 0 {
+					if errs[p] = ctx.Err(); errs[p] != nil {
+						break
+					}
+				}
+				if !in.Match(word) {
+					continue
+				}
+				batch = append(batch, word)
+				if len(batch) == cap(batch) {
+					results <- batch
+					batch = make([]string, 0, batchedSendSize)
+				}
+			}
+			if len(batch) > 0 {
+				results <- batch
+			}
+		}(p, part)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// the collector drains results until every worker is done, so workers
+	// never block on a send after ctx is canceled
+	counts := make(map[string]int)
+	for batch := range results {
+		in.Hooks.Queued(len(results))
+		for _, word := range batch {
+			counts[word]++
+		}
+	}
+	in.Hooks.Queued(0)
+	return counts, firstErr(errs)
+}
@@ -0,0 +1,171 @@
+package e2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestStrategies(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to read book: %v", err)
+	}
+
+	tests := map[string]struct {
+		words []string
+	}{
+		"empty": {
+			words: []string{},
+		},
+		"single": {
+			words: []string{"a"},
+		},
+		"lots": {
+			words: wordlens.TestWords(),
+		},
+		"book": {
+			words: strings.Fields(string(book)),
+		},
+	}
+
+	for name, tc := range tests {
+		for _, workers := range []int{1, 3, 16} {
+			wl := e2.NewWordLens(e2.WithWorkers(workers))
+			expected := wl.FindPalindromes(tc.words, false, e2.TechniqueSequential)
+
+			for _, tech := range e2.Techniques() {
+				t.Run(name+"/"+string(tech), func(t *testing.T) {
+					res := wl.FindPalindromes(tc.words, true, tech)
+					if !reflect.DeepEqual(res, expected) {
+						t.Errorf("workers=%d: expected %d palindromes, got %d", workers, len(expected), len(res))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	calls := 0
+	e2.Register("counting", e2.StrategyFunc(func(ctx context.Context, in e2.Input) (map[string]int, error) {
+		calls++
+		counts := make(map[string]int)
+		for _, word := range in.Words {
+			if in.Match(word) {
+				counts[word]++
+			}
+		}
+		return counts, ctx.Err()
+	}))
+	t.Cleanup(func() { e2.Unregister("counting") })
+
+	if !slices.Contains(e2.Techniques(), "counting") {
+		t.Fatalf("Expected registered technique in %v", e2.Techniques())
+	}
+
+	wl := e2.NewWordLens()
+	res := wl.FindPalindromes([]string{"bob", "alice"}, true, "counting")
+	if calls != 1 {
+		t.Errorf("Expected strategy to be called once, got %d", calls)
+	}
+	if len(res) != 1 || res["bob"] != 1 {
+		t.Errorf("Expected only bob, got %v", res)
+	}
+
+	e2.Unregister("counting")
+	if slices.Contains(e2.Techniques(), "counting") {
+		t.Errorf("Expected unregistered technique to be gone from %v", e2.Techniques())
+	}
+	wl.FindPalindromes([]string{"bob"}, true, "counting")
+	if calls != 1 {
+		t.Errorf("Expected unregistered strategy not to be called, got %d calls", calls)
+	}
+}
+
+func TestStrategiesCanceled(t *testing.T) {
+	words := wordlens.TestWords()
+	wl := e2.NewWordLens(e2.WithWorkers(4))
+
+	for _, tech := range e2.Techniques() {
+		t.Run(string(tech), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			res, err := wl.FindPalindromesContext(ctx, words, tech)
+			if !errors.Is(err, e2.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+				t.Errorf("Expected incomplete and canceled error, got %v", err)
+			}
+			if res == nil {
+				t.Errorf("Expected partial results, got nil")
+			}
+		})
+	}
+}
+
+// cancelingLens cancels its context after analysing limit words.
+type cancelingLens struct {
+	cancel context.CancelFunc
+	limit  int64
+	seen   *atomic.Int64
+}
+
+func (l cancelingLens) Analyze(word string) bool {
+	if l.seen.Add(1) == l.limit {
+		l.cancel()
+	}
+	return true
+}
+
+func (l cancelingLens) Aggregate(counts map[string]int) int {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+func TestStrategiesCanceledMidway(t *testing.T) {
+	var words []string
+	for i := 0; i < 200; i++ {
+		words = append(words, wordlens.TestWords()...)
+	}
+	wl := e2.NewWordLens(e2.WithWorkers(4))
+
+	for _, tech := range e2.Techniques() {
+		t.Run(string(tech), func(t *testing.T) {
+			before := runtime.NumGoroutine()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			lens := cancelingLens{cancel: cancel, limit: 1000, seen: &atomic.Int64{}}
+
+			total, err := e2.AnalyzeContext(ctx, &wl, words, tech, lens)
+			if !errors.Is(err, e2.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected incomplete and canceled error, got %v", err)
+			}
+			if total == 0 || total >= len(words) {
+				t.Errorf("Expected partial count between 0 and %d, got %d", len(words), total)
+			}
+
+			// goroutines may take a moment to be reaped after returning
+			deadline := time.Now().Add(time.Second)
+			for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+				time.Sleep(time.Millisecond)
+			}
+			if n := runtime.NumGoroutine(); n > before {
+				t.Errorf("Expected at most %d goroutines after cancellation, got %d", before, n)
+			}
+		})
+	}
+}
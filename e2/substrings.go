@@ -0,0 +1,279 @@
+package e2
+
+import (
+	"container/heap"
+	"context"
+	"slices"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+)
+
+// Substring is a palindromic run of text found by
+// LongestPalindromicSubstrings. Offset and Length are in bytes of the
+// original text; Runes is the length of the normalized palindrome.
+type Substring struct {
+	Offset int    `json:"offset"`
+	Length int    `json:"length"`
+	Runes  int    `json:"runes"`
+	Text   string `json:"text"`
+}
+
+// normalizedText is text reduced by a normalizer, together with the byte
+// range in the original text that produced each normalized rune.
+type normalizedText struct {
+	runes  []rune
+	starts []int
+	ends   []int
+}
+
+func (wl *WordLens) normalizeText(text string) normalizedText {
+	var normalizer normalize.Normalizer = normalize.All
+	if wl.normalizer != nil {
+		normalizer = wl.normalizer
+	}
+	rules, isRules := normalizer.(normalize.Rules)
+
+	var nt normalizedText
+	var buf []rune
+	for i, c := range text {
+		if isRules {
+			buf = rules.AppendRune(buf[:0], c)
+		} else {
+			buf = append(buf[:0], []rune(normalizer.Normalize(string(c)))...)
+		}
+
+		_, width := utf8.DecodeRuneInString(text[i:])
+		end := i + width
+		for _, r := range buf {
+			nt.runes = append(nt.runes, r)
+			nt.starts = append(nt.starts, i)
+			nt.ends = append(nt.ends, end)
+		}
+	}
+	return nt
+}
+
+// LongestPalindromicSubstrings returns the n longest palindromic substrings
+// of text, longest first, comparing text after normalization (normalize.All
+// unless the WordLens has its own normalizer). Each returned substring is
+// maximal around its centre.
+//
+// The normalized text is split into one chunk per worker and each chunk is
+// analysed with Manacher's algorithm in its own goroutine. Palindromes that
+// reach the edge of a chunk are then found again in a window across the
+// border, or in a single pass over the whole text for those that reach
+// further than a chunk's length past it, so the result matches a single pass
+// over the whole text.
+func (wl *WordLens) LongestPalindromicSubstrings(ctx context.Context, text string, n int) ([]Substring, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nt := wl.normalizeText(text)
+	chunks := max(min(wl.workers, len(nt.runes)/1024), 1)
+	size := (len(nt.runes) + chunks - 1) / chunks
+
+	candidates := make([][]span, chunks)
+	pending := make([][]span, chunks)
+	wg := sync.WaitGroup{}
+	wg.Add(chunks)
+	for c := 0; c < chunks; c++ {
+		go func(c int) {
+			defer wg.Done()
+			lo := min(c*size, len(nt.runes))
+			hi := min(lo+size, len(nt.runes))
+			var spans []span
+			spans, pending[c] = chunkSpans(nt.runes, lo, hi)
+			candidates[c] = topSpans(spans, n)
+		}(c)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var all []span
+	for _, c := range candidates {
+		all = append(all, c...)
+	}
+	if far := slices.Concat(pending...); len(far) > 0 {
+		odd, even := manacher(nt.runes, 0, len(nt.runes))
+		for i := range far {
+			far[i] = around(odd, even, 0, far[i])
+		}
+		all = append(all, topSpans(far, n)...)
+	}
+
+	var res []Substring
+	for _, sp := range topSpans(all, n) {
+		start, end := nt.starts[sp.lo], nt.ends[sp.hi-1]
+		res = append(res, Substring{
+			Offset: start,
+			Length: end - start,
+			Runes:  sp.hi - sp.lo,
+			Text:   text[start:end],
+		})
+	}
+	return res, nil
+}
+
+// span is the half-open range [lo, hi) of a palindrome in normalized runes.
+type span struct {
+	lo, hi int
+}
+
+// chunkSpans returns the maximal palindrome around every centre that lies in
+// s[lo:hi]. It runs Manacher's algorithm on the chunk alone, then again on a
+// window of s around the palindromes that could extend past the chunk,
+// doubling the margin until none can. Margins stop growing at the length of
+// the chunk, so the work stays linear in it; the palindromes still not known
+// to be maximal then, such as in a long run of one rune, are returned as
+// pending for the caller to settle in a single pass over s.
+func chunkSpans(s []rune, lo, hi int) (spans, pending []span) {
+	collect := func(sp span) {
+		switch {
+		case !maximal(s, sp):
+			pending = append(pending, sp)
+		case sp.hi > sp.lo:
+			spans = append(spans, sp)
+		}
+	}
+
+	odd, even := manacher(s, lo, hi)
+	for i := 0; i < len(odd); i++ {
+		collect(odd[i])
+		collect(even[i])
+	}
+
+	for margin := 1; len(pending) > 0; margin *= 2 {
+		wlo, whi := pending[0].lo, pending[0].hi
+		for _, sp := range pending {
+			wlo, whi = min(wlo, sp.lo), max(whi, sp.hi)
+		}
+		if margin = max(margin, whi-wlo); margin > hi-lo {
+			break
+		}
+		wlo, whi = max(wlo-margin, 0), min(whi+margin, len(s))
+
+		odd, even := manacher(s, wlo, whi)
+		centres := pending
+		pending = nil
+		for _, sp := range centres {
+			collect(around(odd, even, wlo, sp))
+		}
+	}
+	return spans, pending
+}
+
+// around returns the palindrome with the same centre as sp from the result
+// of manacher on a window of s starting at wlo.
+func around(odd, even []span, wlo int, sp span) span {
+	// lo+hi is odd for a palindrome centred on a rune and even for one
+	// centred between two
+	c := sp.lo + sp.hi
+	if c%2 == 1 {
+		return odd[c/2-wlo]
+	}
+	return even[c/2-wlo]
+}
+
+// maximal reports whether sp can't be extended within s.
+func maximal(s []rune, sp span) bool {
+	return sp.lo == 0 || sp.hi == len(s) || s[sp.lo-1] != s[sp.hi]
+}
+
+// manacher runs Manacher's algorithm on s[lo:hi] alone. odd[i] is the
+// longest palindrome within s[lo:hi] centred on lo+i and even[i] the longest,
+// possibly empty, centred between lo+i-1 and lo+i.
+func manacher(s []rune, lo, hi int) (odd, even []span) {
+	chunk := s[lo:hi]
+	n := len(chunk)
+	odd = make([]span, n)
+	even = make([]span, n)
+
+	// d1[i] is the radius of the odd palindrome centred on i
+	d1 := make([]int, n)
+	for i, l, r := 0, 0, -1; i < n; i++ {
+		k := 1
+		if i <= r {
+			k = min(d1[l+r-i], r-i+1)
+		}
+		for i-k >= 0 && i+k < n && chunk[i-k] == chunk[i+k] {
+			k++
+		}
+		d1[i] = k
+		if i+k-1 > r {
+			l, r = i-k+1, i+k-1
+		}
+		odd[i] = span{lo: lo + i - k + 1, hi: lo + i + k}
+	}
+
+	// d2[i] is the radius of the even palindrome centred between i-1 and i
+	d2 := make([]int, n)
+	for i, l, r := 0, 0, -1; i < n; i++ {
+		k := 0
+		if i <= r {
+			k = min(d2[l+r-i+1], r-i+1)
+		}
+		for i-k-1 >= 0 && i+k < n && chunk[i-k-1] == chunk[i+k] {
+			k++
+		}
+		d2[i] = k
+		if i+k-1 > r {
+			l, r = i-k, i+k-1
+		}
+		even[i] = span{lo: lo + i - k, hi: lo + i + k}
+	}
+
+	return odd, even
+}
+
+// topSpans returns the n longest spans, longest first and ties broken by
+// position. It keeps a min-heap of n spans, so it runs in O(len(spans) log n).
+func topSpans(spans []span, n int) []span {
+	h := make(spanHeap, 0, n+1)
+	for _, sp := range spans {
+		if len(h) == n && !h.less(0, sp) {
+			continue
+		}
+		heap.Push(&h, sp)
+		if len(h) > n {
+			heap.Pop(&h)
+		}
+	}
+
+	top := make([]span, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		top[i] = heap.Pop(&h).(span)
+	}
+	return top
+}
+
+// spanHeap is a min-heap with the shortest, then right-most, span on top.
+type spanHeap []span
+
+func (h spanHeap) less(i int, sp span) bool {
+	li, l := h[i].hi-h[i].lo, sp.hi-sp.lo
+	if li != l {
+		return li < l
+	}
+	return h[i].lo > sp.lo
+}
+
+func (h spanHeap) Len() int           { return len(h) }
+func (h spanHeap) Less(i, j int) bool { return h.less(i, h[j]) }
+func (h spanHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *spanHeap) Push(x any)        { *h = append(*h, x.(span)) }
+
+func (h *spanHeap) Pop() any {
+	old := *h
+	sp := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return sp
+}
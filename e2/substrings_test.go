@@ -0,0 +1,223 @@
+package e2_test
+
+import (
+	"context"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/e2"
+)
+
+func TestLongestPalindromicSubstrings(t *testing.T) {
+	tests := map[string]struct {
+		text     string
+		n        int
+		expected []e2.Substring
+	}{
+		"empty": {
+			text:     "",
+			n:        3,
+			expected: nil,
+		},
+		"phrase": {
+			text: "He said: A man, a plan, a canal: Panama! and left.",
+			n:    1,
+			expected: []e2.Substring{
+				{Offset: 9, Length: 30, Runes: 21, Text: "A man, a plan, a canal: Panama"},
+			},
+		},
+		"even and odd": {
+			text: "xabbay rotor",
+			n:    2,
+			expected: []e2.Substring{
+				{Offset: 7, Length: 5, Runes: 5, Text: "rotor"},
+				{Offset: 1, Length: 4, Runes: 4, Text: "abba"},
+			},
+		},
+		"invalid utf-8": {
+			text: "a\xff",
+			n:    3,
+			expected: []e2.Substring{
+				{Offset: 0, Length: 1, Runes: 1, Text: "a"},
+				{Offset: 1, Length: 1, Runes: 1, Text: "\xff"},
+			},
+		},
+		"invalid utf-8 inside": {
+			text: "ab\xffba",
+			n:    1,
+			expected: []e2.Substring{
+				{Offset: 0, Length: 5, Runes: 5, Text: "ab\xffba"},
+			},
+		},
+		"normalized": {
+			text: "Été",
+			n:    1,
+			expected: []e2.Substring{
+				{Offset: 0, Length: 5, Runes: 3, Text: "Été"},
+			},
+		},
+	}
+
+	wl := e2.NewWordLens()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := wl.LongestPalindromicSubstrings(context.Background(), tc.text, tc.n)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(res) != len(tc.expected) {
+				t.Fatalf("Expected %d substrings, got %d: %+v", len(tc.expected), len(res), res)
+			}
+			for i := range res {
+				if res[i] != tc.expected[i] {
+					t.Errorf("Expected substring %d to be %+v, got %+v", i, tc.expected[i], res[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLongestPalindromicSubstringsChunked(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var sb strings.Builder
+	for i := 0; i < 20000; i++ {
+		sb.WriteByte("ab"[rng.IntN(2)])
+	}
+	// a long palindrome across the border between the first two chunks
+	text := sb.String()[:10000] + "xyzzyxqxyzzyx" + sb.String()[10000:]
+
+	expected := bruteForceLongest(text)
+	for _, workers := range []int{1, 2, 3, 8} {
+		wl := e2.NewWordLens(e2.WithWorkers(workers))
+		res, err := wl.LongestPalindromicSubstrings(context.Background(), text, 5)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for i := range res {
+			if res[i].Runes != expected[i] {
+				t.Errorf("workers=%d: expected substring %d to have %d runes, got %d", workers, i, expected[i], res[i].Runes)
+			}
+			if !isPalindrome(res[i].Text) {
+				t.Errorf("workers=%d: expected %q to be a palindrome", workers, res[i].Text)
+			}
+		}
+	}
+}
+
+// TestLongestPalindromicSubstringsRepeated has every centre's palindrome
+// cross every chunk border, which must not cost more than linear time.
+// In a run of one rune every palindrome reaches across all the chunks, which
+// must not cost every chunk a pass over the whole text: the memory Manacher's
+// algorithm takes shows how much text was scanned.
+func TestLongestPalindromicSubstringsRepeated(t *testing.T) {
+	text := strings.Repeat("é", 1<<18)
+	runes := len(text) / len("é")
+
+	var single uint64
+	for _, workers := range []int{1, 64} {
+		wl := e2.NewWordLens(e2.WithWorkers(workers))
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		res, err := wl.LongestPalindromicSubstrings(context.Background(), text, 2)
+		runtime.ReadMemStats(&after)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(res) != 2 || res[0].Runes != runes || res[1].Runes != runes-1 {
+			t.Errorf("workers=%d: expected substrings of %d and %d runes, got %+v", workers, runes, runes-1, res)
+		}
+
+		allocated := after.TotalAlloc - before.TotalAlloc
+		if workers == 1 {
+			single = allocated
+		} else if allocated > 4*single {
+			t.Errorf("workers=%d: expected at most %d bytes allocated, got %d", workers, 4*single, allocated)
+		}
+	}
+}
+
+func FuzzLongestPalindromicSubstrings(f *testing.F) {
+	for _, seed := range []string{"", "abba", "a\xff", "ab\xffba", "\xff\xfe\xff", "é\xc3", "A man, a plan"} {
+		f.Add(seed)
+	}
+
+	single := e2.NewWordLens(e2.WithWorkers(1))
+	chunked := e2.NewWordLens(e2.WithWorkers(3))
+	f.Fuzz(func(t *testing.T, text string) {
+		// long enough to be split into chunks
+		if text != "" {
+			text = strings.Repeat(text, 4096/len(text)+1)
+		}
+
+		expected, err := single.LongestPalindromicSubstrings(context.Background(), text, 3)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		got, err := chunked.LongestPalindromicSubstrings(context.Background(), text, 3)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("Expected chunks to find %+v, got %+v", expected, got)
+		}
+		for _, sub := range got {
+			if sub.Offset < 0 || sub.Offset+sub.Length > len(text) || text[sub.Offset:sub.Offset+sub.Length] != sub.Text {
+				t.Errorf("Expected %+v to be a substring of the text", sub)
+			}
+		}
+	})
+}
+
+func TestLongestPalindromicSubstringsBook(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to read book: %v", err)
+	}
+
+	wl := e2.NewWordLens()
+	res, err := wl.LongestPalindromicSubstrings(context.Background(), string(book), 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(res) != 10 {
+		t.Fatalf("Expected 10 substrings, got %d", len(res))
+	}
+	for i := 1; i < len(res); i++ {
+		if res[i].Runes > res[i-1].Runes {
+			t.Errorf("Expected substrings to be sorted by length, got %d after %d", res[i].Runes, res[i-1].Runes)
+		}
+	}
+}
+
+// bruteForceLongest returns the lengths of the maximal palindromes around
+// every centre of an ASCII text, longest first.
+func bruteForceLongest(text string) []int {
+	var lengths []int
+	for c := 0; c < 2*len(text)-1; c++ {
+		l, r := c/2, c/2+c%2
+		for l >= 0 && r < len(text) && text[l] == text[r] {
+			l--
+			r++
+		}
+		if n := r - l - 1; n > 0 {
+			lengths = append(lengths, n)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(lengths)))
+	return lengths
+}
+
+func isPalindrome(text string) bool {
+	for i := 0; i < len(text)/2; i++ {
+		if text[i] != text[len(text)-1-i] {
+			return false
+		}
+	}
+	return true
+}
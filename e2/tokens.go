@@ -0,0 +1,34 @@
+package e2
+
+import (
+	"io"
+
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+// Position locates a word in its source text. Offset is in bytes, Line and
+// Column are 1-based and Column counts runes.
+type Position = tokenize.Position
+
+type token struct {
+	text     string
+	pos, end Position
+}
+
+// tokenScanner splits a reader into words with the WordLens tokenizer, and
+// also records where each word starts and ends.
+type tokenScanner struct {
+	*tokenize.Scanner
+}
+
+func newTokenScanner(rdr io.Reader, r tokenize.Rules) tokenScanner {
+	return tokenScanner{tokenize.NewScanner(rdr, r)}
+}
+
+func (s tokenScanner) Token() token {
+	return token{
+		text: s.Text(),
+		pos:  s.Start(),
+		end:  s.End(),
+	}
+}
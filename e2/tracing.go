@@ -0,0 +1,92 @@
+package e2
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithChildSpans makes a traced WordLens start a child span for every
+// worker goroutine of the local, sharded, batched and workers techniques,
+// and for every batch read by FindPalindromesReader and AnalyzeReader.
+// The per word goroutines of the mutex and channel techniques are never
+// traced.
+func WithChildSpans() Option {
+	return func(wl *WordLens) {
+		wl.childSpans = true
+	}
+}
+
+// traced runs count inside a span called name, recording the technique
+// that ran, the input size, the number of workers and the results.
+func (wl *WordLens) traced(ctx context.Context, name string, words []string, tech Technique, match func(string) bool) (map[string]int, error) {
+	tech, err := wl.resolve(ctx, tech, len(words))
+	if err != nil {
+		return make(map[string]int), err
+	}
+
+	ctx, span := wl.startSpan(ctx, name, tech, attribute.Int("numWords", len(words)))
+	defer span.End()
+
+	counts, err := wl.count(ctx, words, tech, match)
+	endSpan(span, counts, err)
+	return counts, err
+}
+
+// startSpan starts a span called name recording the technique that runs,
+// the number of workers and attrs.
+func (wl *WordLens) startSpan(ctx context.Context, name string, tech Technique, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append([]attribute.KeyValue{
+		attribute.String("technique", string(tech)),
+		attribute.Int("numWorkers", wl.workers),
+	}, attrs...)
+	return otel.Tracer("app").Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records the number of distinct and total matches in counts, and
+// err if any, on span.
+func endSpan(span trace.Span, counts map[string]int, err error) {
+	matches := 0
+	for _, n := range counts {
+		matches += n
+	}
+	span.SetAttributes(
+		attribute.Int("numResults", len(counts)),
+		attribute.Int("numMatches", matches),
+	)
+	recordError(span, err)
+}
+
+// recordError marks span as failed with err, if any.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// startWorkerSpan is the Hooks.OnWorker used WithChildSpans.
+func startWorkerSpan(ctx context.Context, id, words int) func() {
+	_, span := otel.Tracer("app").Start(ctx, "WordLens.worker", trace.WithAttributes(
+		attribute.Int("worker", id),
+		attribute.Int("numWords", words),
+	))
+	return func() { span.End() }
+}
+
+// hooks returns the strategy hooks for a run of tech, or nil when neither
+// metrics nor child spans are enabled.
+func (wl *WordLens) hooks(tech Technique) *Hooks {
+	h := wl.metrics.hooks(tech)
+	if !wl.childSpans {
+		return h
+	}
+	if h == nil {
+		h = &Hooks{}
+	}
+	h.OnWorker = startWorkerSpan
+	return h
+}
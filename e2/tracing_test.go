@@ -0,0 +1,163 @@
+package e2_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
+	"github.com/idiomat/goo11ynyt/e2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans installs a global tracer provider recording every span
+// ended during the test.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	sr := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return sr
+}
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing(t *testing.T) {
+	words := wordlens.TestWords()
+
+	tests := map[string]struct {
+		technique       e2.Technique
+		childSpans      bool
+		expectedWorkers int
+	}{
+		"workers": {
+			technique: e2.TechniqueWorkers,
+		},
+		"workers with child spans": {
+			technique:       e2.TechniqueWorkers,
+			childSpans:      true,
+			expectedWorkers: 3,
+		},
+		"local with child spans": {
+			technique:       e2.TechniqueLocal,
+			childSpans:      true,
+			expectedWorkers: 3,
+		},
+		"mutex with child spans": {
+			technique:  e2.TechniqueMutex,
+			childSpans: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sr := recordSpans(t)
+			opts := []e2.Option{e2.WithWorkers(3)}
+			if tc.childSpans {
+				opts = append(opts, e2.WithChildSpans())
+			}
+			wl := e2.NewWordLens(opts...)
+			res := wl.FindPalindromes(words, true, tc.technique)
+
+			var root sdktrace.ReadOnlySpan
+			workers := 0
+			for _, span := range sr.Ended() {
+				switch span.Name() {
+				case "WordLens.FindPalindromes":
+					root = span
+				case "WordLens.worker":
+					workers++
+				}
+			}
+			if root == nil {
+				t.Fatalf("Expected a WordLens.FindPalindromes span, got %d other spans", len(sr.Ended()))
+			}
+			if workers != tc.expectedWorkers {
+				t.Errorf("Expected %d worker spans, got %d", tc.expectedWorkers, workers)
+			}
+
+			if got := spanAttr(root, "technique").AsString(); got != string(tc.technique) {
+				t.Errorf("Expected technique %q, got %q", tc.technique, got)
+			}
+			if got := spanAttr(root, "numWords").AsInt64(); got != int64(len(words)) {
+				t.Errorf("Expected %d words, got %d", len(words), got)
+			}
+			if got := spanAttr(root, "numWorkers").AsInt64(); got != 3 {
+				t.Errorf("Expected 3 workers, got %d", got)
+			}
+			if got := spanAttr(root, "numResults").AsInt64(); got != int64(len(res)) {
+				t.Errorf("Expected %d results, got %d", len(res), got)
+			}
+
+			for _, span := range sr.Ended() {
+				if span.Name() == "WordLens.worker" && span.Parent().SpanID() != root.SpanContext().SpanID() {
+					t.Errorf("Expected worker span to be a child of %s, got parent %s", root.SpanContext().SpanID(), span.Parent().SpanID())
+				}
+			}
+		})
+	}
+}
+
+func TestTracingReader(t *testing.T) {
+	f, err := os.Open("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer f.Close()
+
+	sr := recordSpans(t)
+	wl := e2.NewWordLens(e2.WithChildSpans())
+	if _, err := wl.FindPalindromesReader(context.Background(), f, e2.TechniqueSequential); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var root sdktrace.ReadOnlySpan
+	batches := 0
+	for _, span := range sr.Ended() {
+		switch span.Name() {
+		case "WordLens.FindPalindromesReader":
+			root = span
+		case "WordLens.batch":
+			batches++
+		}
+	}
+	if root == nil {
+		t.Fatal("Expected a WordLens.FindPalindromesReader span")
+	}
+	if got := spanAttr(root, "numBatches").AsInt64(); got != int64(batches) || batches < 2 {
+		t.Errorf("Expected numBatches to match %d batch spans, got %d", batches, got)
+	}
+}
+
+func TestTracingCanceled(t *testing.T) {
+	sr := recordSpans(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wl := e2.NewWordLens()
+	_, err := wl.FindPalindromesContext(ctx, wordlens.TestWords(), e2.TechniqueSequential)
+	if !errors.Is(err, e2.ErrIncomplete) {
+		t.Fatalf("Expected ErrIncomplete, got %v", err)
+	}
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if got := spans[0].Status().Code; got != codes.Error {
+		t.Errorf("Expected status %v, got %v", codes.Error, got)
+	}
+}
@@ -1,121 +1,233 @@
 package e2
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"runtime"
-	"sync"
+	"time"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// DefaultBatchSize is the number of words FindPalindromesReader buffers
+// before handing them to a technique.
+const DefaultBatchSize = 4096
+
 type WordLens struct {
-	mu      sync.Mutex
-	workers int
+	workers         int
+	batchSize       int
+	normalizer      normalize.Normalizer
+	tokens          tokenize.Rules
+	calibration     *Calibration
+	autoCalibration *lazyCalibration
+	metrics         *Metrics
+	childSpans      bool
 }
 
-func NewWordLens() WordLens {
-	return WordLens{
-		mu:      sync.Mutex{},
-		workers: runtime.NumCPU(),
+type Option func(*WordLens)
+
+// WithNormalizer makes every technique compare words after normalizing
+// them, e.g. with normalize.All to ignore case, punctuation, diacritics and
+// whitespace. Results are still keyed by the original word.
+func WithNormalizer(n normalize.Normalizer) Option {
+	return func(wl *WordLens) {
+		wl.normalizer = n
 	}
 }
 
-type Technique string
+// WithTokenizer sets how readers are split into words, e.g. with
+// tokenize.All so that "print." and "print" are the same word. It defaults
+// to tokenize.None, which splits around white space like bufio.ScanWords.
+func WithTokenizer(r tokenize.Rules) Option {
+	return func(wl *WordLens) {
+		wl.tokens = r
+	}
+}
 
-const (
-	TechniqueMutex   Technique = "mutex"
-	TechniqueChannel Technique = "channel"
-	TechniqueWorkers Technique = "workers"
-)
+// WithWorkers sets how many goroutines the worker based techniques use. It
+// defaults to runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(wl *WordLens) {
+		if n > 0 {
+			wl.workers = n
+		}
+	}
+}
+
+func NewWordLens(opts ...Option) WordLens {
+	wl := WordLens{
+		workers:   runtime.NumCPU(),
+		batchSize: DefaultBatchSize,
+	}
+	for _, opt := range opts {
+		opt(&wl)
+	}
+	return wl
+}
+
+// ErrIncomplete is returned, wrapping the context's error, when a search was
+// stopped before every word was checked. The counts returned with it are
+// the ones gathered up to that point.
+var ErrIncomplete = errors.New("e2: incomplete results")
 
 func (wl *WordLens) FindPalindromes(words []string, useConcurrency bool, tech Technique) map[string]int {
-	palindromes := make(map[string]int)
-
-	switch tech {
-	case TechniqueMutex:
-		wg := sync.WaitGroup{}
-		wg.Add(len(words))
-
-		for _, word := range words {
-			go func(word string) {
-				defer wg.Done()
-				if wl.isPalindrome(word) {
-					wl.mu.Lock()
-					palindromes[word]++
-					wl.mu.Unlock()
-				}
-			}(word)
-		}
+	palindromes, _ := wl.FindPalindromesContext(context.Background(), words, tech)
+	return palindromes
+}
 
-		wg.Wait()
-	case TechniqueChannel:
-		wg := sync.WaitGroup{}
-		wg.Add(len(words))
-		results := make(chan string, len(words))
-
-		for _, word := range words {
-			go func(word string) {
-				defer wg.Done()
-				if wl.isPalindrome(word) {
-					results <- word
-				}
-			}(word)
-		}
+// FindPalindromesContext is like FindPalindromes but stops every goroutine
+// it started once ctx is done, returning the partial counts and an error
+// matching both ErrIncomplete and ctx.Err().
+func (wl *WordLens) FindPalindromesContext(ctx context.Context, words []string, tech Technique) (map[string]int, error) {
+	return wl.traced(ctx, "WordLens.FindPalindromes", words, tech, wl.isPalindrome)
+}
+
+// count runs the strategy registered for tech over words and counts every
+// word for which match reports true.
+func (wl *WordLens) count(ctx context.Context, words []string, tech Technique, match func(string) bool) (map[string]int, error) {
+	tech, err := wl.resolve(ctx, tech, len(words))
+	if err != nil {
+		return make(map[string]int), err
+	}
 
-		go func() {
-			wg.Wait()
-			close(results)
-		}()
+	start := time.Now()
+	counts, err := lookup(tech).Count(ctx, Input{
+		Words:   words,
+		Match:   match,
+		Workers: wl.workers,
+		Hooks:   wl.hooks(tech),
+	})
+	wl.metrics.observe(tech, len(words), counts, time.Since(start), err)
+	if err != nil {
+		return counts, fmt.Errorf("%w: %w", ErrIncomplete, err)
+	}
+	return counts, nil
+}
+
+// FindPalindromesReader tokenizes rdr with the WordLens tokenizer and runs
+// tech over batches of at most DefaultBatchSize words, so memory use stays
+// bounded by the batch rather than the size of the input.
+func (wl *WordLens) FindPalindromesReader(ctx context.Context, rdr io.Reader, tech Technique) (map[string]int, error) {
+	return wl.countReader(ctx, "WordLens.FindPalindromesReader", rdr, tech, wl.isPalindrome)
+}
 
-		for word := range results {
-			palindromes[word]++
+func (wl *WordLens) countReader(ctx context.Context, name string, rdr io.Reader, tech Technique, match func(string) bool) (map[string]int, error) {
+	counts := make(map[string]int)
+	err := wl.foldReader(ctx, name, rdr, tech, match, false, func(_ batch, res map[string]int, _ error) {
+		for word, n := range res {
+			counts[word] += n
 		}
-	case TechniqueWorkers:
-		jobs := make(chan string, len(words))
-		results := make(chan string)
+	})
+	return counts, err
+}
 
-		// start workers
-		for w := 0; w < wl.workers; w++ {
-			go wl.worker(jobs, results)
+// batch is a batch of words read by foldReader and, when positions were
+// asked for, where each of them starts.
+type batch struct {
+	words     []string
+	positions []Position
+}
+
+// foldReader runs match over the words read from rdr inside a span called
+// name, recording attrs on it, and passes every batch with its counts to
+// fold, along with the error that cut the count short, if any. Batches are
+// traced as child spans when WithChildSpans is set.
+func (wl *WordLens) foldReader(ctx context.Context, name string, rdr io.Reader, tech Technique, match func(string) bool, positions bool, fold func(b batch, res map[string]int, err error), attrs ...attribute.KeyValue) (err error) {
+	ctx, span := wl.startSpan(ctx, name, tech, append(attrs, attribute.Int("batchSize", wl.batchLen()))...)
+	defer span.End()
+
+	b := batch{words: make([]string, 0, wl.batchLen())}
+	words, batches, matches := 0, 0, 0
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("numWords", words),
+			attribute.Int("numBatches", batches),
+			attribute.Int("numMatches", matches),
+		)
+		recordError(span, err)
+	}()
+
+	flush := func() error {
+		res, err := wl.countBatch(ctx, b.words, tech, match)
+		for _, n := range res {
+			matches += n
 		}
+		fold(b, res, err)
+		words += len(b.words)
+		batches++
+		b.words, b.positions = b.words[:0], b.positions[:0]
+		return err
+	}
 
-		// start jobs
-		go func() {
-			for _, word := range words {
-				jobs <- word
-			}
-			close(jobs)
-		}()
+	var scanner interface {
+		Scan() bool
+		Text() string
+		Err() error
+	}
+	var tokens tokenScanner
+	if positions {
+		tokens = newTokenScanner(rdr, wl.tokens)
+		scanner = tokens
+	} else {
+		plain := bufio.NewScanner(rdr)
+		plain.Buffer(nil, tokenize.MaxWordSize)
+		plain.Split(wl.tokens.SplitFunc())
+		scanner = plain
+	}
 
-		// collect results
-		for i := 0; i < len(words); i++ {
-			if word := <-results; word != "" {
-				palindromes[word]++
-			}
+	for scanner.Scan() {
+		b.words = append(b.words, scanner.Text())
+		if positions {
+			b.positions = append(b.positions, tokens.Start())
 		}
-		close(results)
-	default:
-		for _, word := range words {
-			if wl.isPalindrome(word) {
-				palindromes[word]++
+		if len(b.words) == cap(b.words) {
+			if err := flush(); err != nil {
+				return err
 			}
 		}
 	}
-	return palindromes
-}
 
-func (wl *WordLens) worker(jobs <-chan string, results chan<- string) {
-	for word := range jobs {
-		if wl.isPalindrome(word) {
-			results <- word
-		} else {
-			results <- ""
+	// words read before a read error are still counted
+	if len(b.words) > 0 {
+		if err := flush(); err != nil {
+			return err
 		}
 	}
+	return scanner.Err()
+}
+
+// batchLen returns the number of words read per batch, which falls back to
+// DefaultBatchSize for a WordLens not made with NewWordLens.
+func (wl *WordLens) batchLen() int {
+	if wl.batchSize <= 0 {
+		return DefaultBatchSize
+	}
+	return wl.batchSize
+}
+
+// countBatch counts one batch of a reader, in its own span when
+// WithChildSpans is set.
+func (wl *WordLens) countBatch(ctx context.Context, batch []string, tech Technique, match func(string) bool) (map[string]int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrIncomplete, err)
+	}
+	if wl.childSpans {
+		return wl.traced(ctx, "WordLens.batch", batch, tech, match)
+	}
+	return wl.count(ctx, batch, tech, match)
 }
 
 func (wl *WordLens) isPalindrome(word string) bool {
-	for i := range word {
-		if word[i] != word[len(word)-1-i] {
+	if wl.normalizer != nil {
+		if word = wl.normalizer.Normalize(word); word == "" {
 			return false
 		}
 	}
-	return true
+	return palindrome.Runes(word)
 }
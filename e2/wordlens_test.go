@@ -1,15 +1,24 @@
 package e2_test
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"flag"
+	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/idiomat/goo11ynyt/benchmarking/wordlens"
 	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/idiomat/goo11ynyt/text/normalize"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
 )
 
 var technique = flag.String("technique", "sequential", "run the benchmark with the specified technique")
+var maxWords = flag.Int("words", 1_000_000, "run BenchmarkFindPalindromesCorpus with generated corpora of up to this many words")
 
 func TestFindPalindromes(t *testing.T) {
 	tests := map[string]struct {
@@ -46,6 +55,188 @@ func TestFindPalindromes(t *testing.T) {
 	}
 }
 
+func TestFindPalindromesNormalized(t *testing.T) {
+	tests := map[string]struct {
+		words    []string
+		expected int
+	}{
+		"mixed case": {
+			words:    []string{"Level", "Racecar", "Hello"},
+			expected: 2,
+		},
+		"punctuation": {
+			words:    []string{"Madam,", "(bob)", "tenet.", "print."},
+			expected: 3,
+		},
+		"diacritics": {
+			words:    []string{"été", "Ésope", "ÀnnA"},
+			expected: 2,
+		},
+		"phrases": {
+			words:    []string{"Step on no pets!", "A man, a plan, a canal: Panama", "not a palindrome"},
+			expected: 2,
+		},
+		"only punctuation": {
+			words:    []string{",", "--"},
+			expected: 0,
+		},
+	}
+
+	techniques := e2.Techniques()
+	wl := e2.NewWordLens(e2.WithNormalizer(normalize.All))
+
+	for name, tc := range tests {
+		for _, tech := range techniques {
+			t.Run(name+"/"+string(tech), func(t *testing.T) {
+				res := wl.FindPalindromes(tc.words, tech != e2.TechniqueSequential, tech)
+				if len(res) != tc.expected {
+					t.Errorf("Expected %d palindromes, got %d", tc.expected, len(res))
+				}
+			})
+		}
+	}
+}
+
+func TestFindPalindromesReader(t *testing.T) {
+	book, err := os.ReadFile("../data/pg2680.txt")
+	if err != nil {
+		t.Fatalf("failed to read book: %v", err)
+	}
+
+	tests := map[string]struct {
+		text string
+	}{
+		"empty": {
+			text: "",
+		},
+		"lots": {
+			text: strings.Join(wordlens.TestWords(), "\n"),
+		},
+		"book": {
+			text: string(book),
+		},
+	}
+
+	techniques := e2.Techniques()
+	wl := e2.NewWordLens()
+
+	for name, tc := range tests {
+		expected := wl.FindPalindromes(strings.Fields(tc.text), false, e2.TechniqueSequential)
+		for _, tech := range techniques {
+			t.Run(name+"/"+string(tech), func(t *testing.T) {
+				res, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(tc.text), tech)
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if len(res) != len(expected) {
+					t.Fatalf("Expected %d palindromes, got %d", len(expected), len(res))
+				}
+				for word, n := range expected {
+					if res[word] != n {
+						t.Errorf("Expected %q to be found %d times, got %d", word, n, res[word])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestFindPalindromesTokenized(t *testing.T) {
+	text := "(Noon) noon, level.\nre-\nfer wow—wow"
+
+	tests := map[string]struct {
+		rules      tokenize.Rules
+		expected   map[string]int
+		firstLevel e2.Position
+	}{
+		"none": {
+			rules:      tokenize.None,
+			expected:   map[string]int{"wow—wow": 1},
+			firstLevel: e2.Position{},
+		},
+		"punct": {
+			rules:      tokenize.StripPunctuation,
+			expected:   map[string]int{"noon": 1, "level": 1, "wow—wow": 1},
+			firstLevel: e2.Position{Offset: 13, Line: 1, Column: 14},
+		},
+		"all": {
+			rules:      tokenize.All,
+			expected:   map[string]int{"noon": 1, "level": 1, "refer": 1, "wow": 2},
+			firstLevel: e2.Position{Offset: 13, Line: 1, Column: 14},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			wl := e2.NewWordLens(e2.WithTokenizer(tc.rules))
+			res, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(text), e2.TechniqueSequential)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, res)
+			}
+
+			occs, err := wl.FindPalindromeOccurrences(context.Background(), strings.NewReader(text), e2.TechniqueSequential, 1)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var got e2.Position
+			if occ := occs["level"]; occ != nil {
+				got = occ.Positions[0]
+			}
+			if got != tc.firstLevel {
+				t.Errorf("Expected level at %v, got %v", tc.firstLevel, got)
+			}
+		})
+	}
+}
+
+func TestFindPalindromesReaderLongWords(t *testing.T) {
+	long := strings.Repeat("a", 70_000) // longer than a bufio.Scanner token by default
+
+	tests := map[string]struct {
+		text     string
+		expected map[string]int
+		err      error
+	}{
+		"long word": {
+			text:     "noon " + long + " level",
+			expected: map[string]int{"noon": 1, long: 1, "level": 1},
+		},
+		// words read before the error are kept
+		"too long": {
+			text:     "noon level " + strings.Repeat("ab", tokenize.MaxWordSize/2+1),
+			expected: map[string]int{"noon": 1, "level": 1},
+			err:      bufio.ErrTooLong,
+		},
+	}
+
+	wl := e2.NewWordLens()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := wl.FindPalindromesReader(context.Background(), strings.NewReader(tc.text), e2.TechniqueSequential)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("Expected %d palindromes, got %d", len(tc.expected), len(res))
+			}
+		})
+	}
+}
+
+func TestFindPalindromesReaderCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wl := e2.NewWordLens()
+	_, err := wl.FindPalindromesReader(ctx, strings.NewReader("bob tenet"), e2.TechniqueWorkers)
+	if !errors.Is(err, e2.ErrIncomplete) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected incomplete and canceled error, got %v", err)
+	}
+}
+
 func BenchmarkFindPalindromes(b *testing.B) {
 	b.StopTimer() // exclude preparations from the benchmark
 	flag.Parse()
@@ -63,3 +254,33 @@ func BenchmarkFindPalindromes(b *testing.B) {
 		})
 	}
 }
+
+// BenchmarkFindPalindromesCorpus measures how FindPalindromes scales with
+// the number of words, on generated corpora of several shapes.
+func BenchmarkFindPalindromesCorpus(b *testing.B) {
+	b.StopTimer() // exclude preparations from the benchmark
+	flag.Parse()
+	wl := e2.NewWordLens()
+	concurrent := *technique != "sequential"
+	sizes := wordlens.CorpusSizes(*maxWords)
+
+	for _, shape := range wordlens.CorpusShapes() {
+		opts := shape.Options
+		opts.Words = sizes[len(sizes)-1]
+		allWords, err := wordlens.GenerateCorpus(opts)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		b.StartTimer() // run the benchmark
+		for _, n := range sizes {
+			words := allWords[:n]
+			b.Run("shape="+shape.Name+"/words="+strconv.Itoa(n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					wl.FindPalindromes(words, concurrent, e2.Technique(*technique))
+				}
+			})
+		}
+		b.StopTimer()
+	}
+}
@@ -1,18 +1,33 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
+	"github.com/idiomat/goo11ynyt/e2"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var port = flag.String("port", "2112", "Port to listen on")
+var book = flag.String("book", "", "Book to analyse with every wordlens technique so its metrics are populated")
+var interval = flag.Duration("interval", 10*time.Second, "How often to analyse the book")
 
 func main() {
 	flag.Parse()
 
+	if *book != "" {
+		m, err := e2.NewMetrics(prometheus.DefaultRegisterer)
+		if err != nil {
+			log.Fatalf("failed to register wordlens metrics: %v", err)
+		}
+		go analyse(e2.NewWordLens(e2.WithMetrics(m)), *book, *interval)
+	}
+
 	http.Handle("/metrics", promhttp.Handler())
 
 	log.Printf("listening on :%s\n", *port)
@@ -20,3 +35,19 @@ func main() {
 		log.Fatalf("failed to listen and serve: %v", err)
 	}
 }
+
+func analyse(wl e2.WordLens, path string, interval time.Duration) {
+	for {
+		for _, tech := range e2.Techniques() {
+			f, err := os.Open(path)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			if _, err := wl.FindPalindromesReader(context.Background(), f, tech); err != nil {
+				log.Printf("failed to analyse %s with %s: %v", path, tech, err)
+			}
+			f.Close()
+		}
+		time.Sleep(interval)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/idiomat/goo11ynyt/text/tokenize"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
@@ -18,6 +19,17 @@ const DefaultChunkOverlap = 128
 type Chunker struct {
 	chunkSize    int
 	chunkOverlap int
+	tokens       tokenize.Rules
+}
+
+type ChunkerOption func(*Chunker)
+
+// WithTokenizer sets how text is split into the words that chunks are
+// counted in. It defaults to tokenize.None, which splits around white space.
+func WithTokenizer(r tokenize.Rules) ChunkerOption {
+	return func(c *Chunker) {
+		c.tokens = r
+	}
 }
 
 func (c *Chunker) validate() error {
@@ -30,8 +42,11 @@ func (c *Chunker) validate() error {
 	return nil
 }
 
-func NewChunker(chunkSize, chunkOverlap int) (*Chunker, error) {
+func NewChunker(chunkSize, chunkOverlap int, opts ...ChunkerOption) (*Chunker, error) {
 	c := &Chunker{chunkSize: chunkSize, chunkOverlap: chunkOverlap}
+	for _, opt := range opts {
+		opt(c)
+	}
 	if c.chunkSize == 0 {
 		c.chunkSize = DefaultChunkSize
 	}
@@ -45,6 +60,7 @@ func (c *Chunker) Chunk(ctx context.Context, rdr io.Reader) ([]string, error) {
 	_, span := otel.Tracer("app").Start(ctx, "Chunker.Chunk", trace.WithAttributes(
 		attribute.Int("chunkSize", c.chunkSize),
 		attribute.Int("chunkOverlap", c.chunkOverlap),
+		attribute.String("tokenizer", c.tokens.String()),
 	))
 	defer span.End()
 
@@ -53,28 +69,26 @@ func (c *Chunker) Chunk(ctx context.Context, rdr io.Reader) ([]string, error) {
 	var currentChunkWords int               // keeps track of the number of words in the current chunk
 
 	scanner := bufio.NewScanner(rdr)
+	scanner.Split(c.tokens.SplitFunc()) // read the text word by word
 	for scanner.Scan() {
-		words := strings.Fields(scanner.Text()) // split the line into words
-		for _, word := range words {
-			if currentChunkWords > 0 {
-				currentChunkBuilder.WriteString(" ") // add a space before adding the next word
-			}
-			currentChunkBuilder.WriteString(word) // add the word to the current chunk
-			currentChunkWords++                   // increment the number of words in the current chunk
+		if currentChunkWords > 0 {
+			currentChunkBuilder.WriteString(" ") // add a space before adding the next word
+		}
+		currentChunkBuilder.WriteString(scanner.Text()) // add the word to the current chunk
+		currentChunkWords++                             // increment the number of words in the current chunk
 
-			// build the full chunk
-			if currentChunkWords >= c.chunkSize {
-				chunks = append(chunks, currentChunkBuilder.String())
-				overlapWords := strings.Fields(currentChunkBuilder.String())
-				currentChunkBuilder.Reset()
-				currentChunkWords = 0
-				for i := len(overlapWords) - c.chunkOverlap; i < len(overlapWords); i++ {
-					if currentChunkWords > 0 {
-						currentChunkBuilder.WriteString(" ")
-					}
-					currentChunkBuilder.WriteString(overlapWords[i])
-					currentChunkWords++
+		// build the full chunk
+		if currentChunkWords >= c.chunkSize {
+			chunks = append(chunks, currentChunkBuilder.String())
+			overlapWords := strings.Fields(currentChunkBuilder.String())
+			currentChunkBuilder.Reset()
+			currentChunkWords = 0
+			for i := len(overlapWords) - c.chunkOverlap; i < len(overlapWords); i++ {
+				if currentChunkWords > 0 {
+					currentChunkBuilder.WriteString(" ")
 				}
+				currentChunkBuilder.WriteString(overlapWords[i])
+				currentChunkWords++
 			}
 		}
 	}
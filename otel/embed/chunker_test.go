@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/idiomat/goo11ynyt/otel/embed"
+	"github.com/idiomat/goo11ynyt/text/tokenize"
 )
 
 func TestChunker(t *testing.T) {
@@ -13,6 +14,7 @@ func TestChunker(t *testing.T) {
 		input          string
 		chunkSize      int
 		chunkOverlap   int
+		rules          tokenize.Rules
 		expectedChunks []string
 	}{
 		"simple": {
@@ -26,11 +28,22 @@ func TestChunker(t *testing.T) {
 				"chunking",
 			},
 		},
+		"tokenized": {
+			input:        "\"Step on, no pets.\" It's well-\nknown!",
+			chunkSize:    3,
+			chunkOverlap: 1,
+			rules:        tokenize.All,
+			expectedChunks: []string{
+				"Step on no",
+				"no pets It's",
+				"It's wellknown",
+			},
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			chunker, err := embed.NewChunker(tt.chunkSize, tt.chunkOverlap)
+			chunker, err := embed.NewChunker(tt.chunkSize, tt.chunkOverlap, embed.WithTokenizer(tt.rules))
 			if err != nil {
 				t.Fatalf("Expected no error while creating chunker, got: %v", err)
 			}
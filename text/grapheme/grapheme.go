@@ -0,0 +1,166 @@
+// Package grapheme segments text into extended grapheme clusters as defined
+// by Unicode Standard Annex #29, i.e. the units a reader perceives as single
+// characters: "é" written as "e" plus a combining accent, a flag made of two
+// regional indicators, or an emoji joined with ZWJ.
+package grapheme
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type property uint8
+
+const (
+	pAny property = iota
+	pCR
+	pLF
+	pControl
+	pExtend
+	pZWJ
+	pRegionalIndicator
+	pPrepend
+	pSpacingMark
+	pL
+	pV
+	pT
+	pLV
+	pLVT
+	pExtendedPictographic
+)
+
+func propertyOf(r rune) property {
+	switch {
+	case r == '\r':
+		return pCR
+	case r == '\n':
+		return pLF
+	case r < 0x20, r >= 0x7F && r < 0xA0:
+		return pControl
+	case r < 0x7F:
+		return pAny
+	case r == 0x200D:
+		return pZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return pRegionalIndicator
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return pL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return pV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return pT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return pLV
+		}
+		return pLVT
+	case unicode.In(r, unicode.Mn, unicode.Me, otherExtend):
+		return pExtend
+	case unicode.Is(prepend, r):
+		return pPrepend
+	case r == 0x0E33, r == 0x0EB3:
+		return pSpacingMark
+	case unicode.Is(unicode.Mc, r) && !unicode.Is(spacingMarkExceptions, r):
+		return pSpacingMark
+	case unicode.In(r, unicode.Zl, unicode.Zp, unicode.Cf, unicode.Cc):
+		return pControl
+	case unicode.Is(extendedPictographic, r):
+		return pExtendedPictographic
+	}
+	return pAny
+}
+
+// Next returns the length in bytes of the grapheme cluster at the start of s.
+func Next(s string) int {
+	if s == "" {
+		return 0
+	}
+
+	r, n := utf8.DecodeRuneInString(s)
+	prev := propertyOf(r)
+
+	// state carried across the cluster for rules GB11 to GB13
+	regionalIndicators := 0
+	if prev == pRegionalIndicator {
+		regionalIndicators++
+	}
+	emoji := prev == pExtendedPictographic
+	emojiZWJ := false
+
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		cur := propertyOf(r)
+
+		if breakBetween(prev, cur, regionalIndicators, emojiZWJ) {
+			break
+		}
+
+		if cur == pRegionalIndicator {
+			regionalIndicators++
+		}
+		emojiZWJ = emoji && cur == pZWJ
+		emoji = cur == pExtendedPictographic || (emoji && cur == pExtend)
+
+		prev = cur
+		n += size
+	}
+	return n
+}
+
+func breakBetween(prev, cur property, regionalIndicators int, emojiZWJ bool) bool {
+	switch {
+	case prev == pCR && cur == pLF: // GB3
+		return false
+	case prev == pCR, prev == pLF, prev == pControl: // GB4
+		return true
+	case cur == pCR, cur == pLF, cur == pControl: // GB5
+		return true
+	case prev == pL && (cur == pL || cur == pV || cur == pLV || cur == pLVT): // GB6
+		return false
+	case (prev == pLV || prev == pV) && (cur == pV || cur == pT): // GB7
+		return false
+	case (prev == pLVT || prev == pT) && cur == pT: // GB8
+		return false
+	case cur == pExtend, cur == pZWJ: // GB9
+		return false
+	case cur == pSpacingMark: // GB9a
+		return false
+	case prev == pPrepend: // GB9b
+		return false
+	case prev == pZWJ && cur == pExtendedPictographic && emojiZWJ: // GB11
+		return false
+	case prev == pRegionalIndicator && cur == pRegionalIndicator: // GB12, GB13
+		return regionalIndicators%2 == 0
+	}
+	return true // GB999
+}
+
+// Clusters splits s into its extended grapheme clusters.
+func Clusters(s string) []string {
+	var clusters []string
+	for s != "" {
+		n := Next(s)
+		clusters = append(clusters, s[:n])
+		s = s[n:]
+	}
+	return clusters
+}
+
+// Boundaries appends the byte offset at which each grapheme cluster of s
+// starts, followed by len(s), to dst and returns the extended slice.
+func Boundaries(dst []int, s string) []int {
+	for i := 0; i < len(s); i += Next(s[i:]) {
+		dst = append(dst, i)
+	}
+	return append(dst, len(s))
+}
+
+// Count returns the number of grapheme clusters in s.
+func Count(s string) int {
+	n := 0
+	for s != "" {
+		s = s[Next(s):]
+		n++
+	}
+	return n
+}
@@ -0,0 +1,97 @@
+package grapheme_test
+
+import (
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/text/grapheme"
+)
+
+func TestClusters(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected []string
+	}{
+		"empty": {
+			input:    "",
+			expected: nil,
+		},
+		"ascii": {
+			input:    "bob",
+			expected: []string{"b", "o", "b"},
+		},
+		"crlf": {
+			input:    "a\r\nb",
+			expected: []string{"a", "\r\n", "b"},
+		},
+		"combining mark": {
+			input:    "e\u0301te\u0301",
+			expected: []string{"e\u0301", "t", "e\u0301"},
+		},
+		"precomposed": {
+			input:    "été",
+			expected: []string{"é", "t", "é"},
+		},
+		"flags": {
+			input:    "🇫🇷🇩🇪🇮",
+			expected: []string{"🇫🇷", "🇩🇪", "🇮"},
+		},
+		"emoji modifier": {
+			input:    "👍🏽!",
+			expected: []string{"👍🏽", "!"},
+		},
+		"zwj sequence": {
+			input:    "👨\u200d👩\u200d👧x",
+			expected: []string{"👨\u200d👩\u200d👧", "x"},
+		},
+		"zwj without emoji": {
+			input:    "a\u200d👩",
+			expected: []string{"a\u200d", "👩"},
+		},
+		"hangul jamo": {
+			input:    "\u1100\u1161\u11a8\uac00",
+			expected: []string{"\u1100\u1161\u11a8", "\uac00"},
+		},
+		"spacing mark": {
+			input:    "कि",
+			expected: []string{"कि"},
+		},
+		"prepend": {
+			input:    "\u0600\u0661",
+			expected: []string{"\u0600\u0661"},
+		},
+		"invalid utf-8": {
+			input:    "a\xffb",
+			expected: []string{"a", "\xff", "b"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := grapheme.Clusters(tc.input)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("Expected %q, got %q", tc.expected, got)
+			}
+			for i := range got {
+				if got[i] != tc.expected[i] {
+					t.Errorf("Expected cluster %d to be %q, got %q", i, tc.expected[i], got[i])
+				}
+			}
+			if n := grapheme.Count(tc.input); n != len(tc.expected) {
+				t.Errorf("Expected count %d, got %d", len(tc.expected), n)
+			}
+		})
+	}
+}
+
+func TestBoundaries(t *testing.T) {
+	got := grapheme.Boundaries(nil, "ét🇫🇷")
+	expected := []int{0, 3, 4, 12}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("Expected boundary %d to be %d, got %d", i, expected[i], got[i])
+		}
+	}
+}
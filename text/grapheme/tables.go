@@ -0,0 +1,171 @@
+package grapheme
+
+import "unicode"
+
+// The tables below cover the Grapheme_Cluster_Break properties that cannot be
+// derived from the general categories in the unicode package. They follow
+// GraphemeBreakProperty.txt and emoji-data.txt.
+
+var prepend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x0600, Hi: 0x0605, Stride: 1},
+		{Lo: 0x06DD, Hi: 0x06DD, Stride: 1},
+		{Lo: 0x070F, Hi: 0x070F, Stride: 1},
+		{Lo: 0x0890, Hi: 0x0891, Stride: 1},
+		{Lo: 0x08E2, Hi: 0x08E2, Stride: 1},
+		{Lo: 0x0D4E, Hi: 0x0D4E, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x110BD, Hi: 0x110BD, Stride: 1},
+		{Lo: 0x110CD, Hi: 0x110CD, Stride: 1},
+		{Lo: 0x111C2, Hi: 0x111C3, Stride: 1},
+		{Lo: 0x1193F, Hi: 0x1193F, Stride: 1},
+		{Lo: 0x11941, Hi: 0x11941, Stride: 1},
+		{Lo: 0x11A3A, Hi: 0x11A3A, Stride: 1},
+		{Lo: 0x11A84, Hi: 0x11A89, Stride: 1},
+		{Lo: 0x11D46, Hi: 0x11D46, Stride: 1},
+	},
+}
+
+// otherExtend lists runes with Grapheme_Cluster_Break=Extend that are not
+// nonspacing or enclosing marks: Other_Grapheme_Extend, ZWNJ, the emoji
+// modifiers and the tag characters.
+var otherExtend = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x09BE, Hi: 0x09BE, Stride: 1},
+		{Lo: 0x09D7, Hi: 0x09D7, Stride: 1},
+		{Lo: 0x0B3E, Hi: 0x0B3E, Stride: 1},
+		{Lo: 0x0B57, Hi: 0x0B57, Stride: 1},
+		{Lo: 0x0BBE, Hi: 0x0BBE, Stride: 1},
+		{Lo: 0x0BD7, Hi: 0x0BD7, Stride: 1},
+		{Lo: 0x0CC2, Hi: 0x0CC2, Stride: 1},
+		{Lo: 0x0CD5, Hi: 0x0CD6, Stride: 1},
+		{Lo: 0x0D3E, Hi: 0x0D3E, Stride: 1},
+		{Lo: 0x0D57, Hi: 0x0D57, Stride: 1},
+		{Lo: 0x0DCF, Hi: 0x0DCF, Stride: 1},
+		{Lo: 0x0DDF, Hi: 0x0DDF, Stride: 1},
+		{Lo: 0x1B35, Hi: 0x1B35, Stride: 1},
+		{Lo: 0x200C, Hi: 0x200C, Stride: 1},
+		{Lo: 0x302E, Hi: 0x302F, Stride: 1},
+		{Lo: 0xFF9E, Hi: 0xFF9F, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1133E, Hi: 0x1133E, Stride: 1},
+		{Lo: 0x11357, Hi: 0x11357, Stride: 1},
+		{Lo: 0x114B0, Hi: 0x114B0, Stride: 1},
+		{Lo: 0x114BD, Hi: 0x114BD, Stride: 1},
+		{Lo: 0x115AF, Hi: 0x115AF, Stride: 1},
+		{Lo: 0x11930, Hi: 0x11930, Stride: 1},
+		{Lo: 0x1D165, Hi: 0x1D165, Stride: 1},
+		{Lo: 0x1D16E, Hi: 0x1D172, Stride: 1},
+		{Lo: 0x1F3FB, Hi: 0x1F3FF, Stride: 1},
+		{Lo: 0xE0020, Hi: 0xE007F, Stride: 1},
+	},
+}
+
+// spacingMarkExceptions are spacing combining marks (Mc) that do not have
+// Grapheme_Cluster_Break=SpacingMark.
+var spacingMarkExceptions = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x102B, Hi: 0x102C, Stride: 1},
+		{Lo: 0x1038, Hi: 0x1038, Stride: 1},
+		{Lo: 0x1062, Hi: 0x1064, Stride: 1},
+		{Lo: 0x1067, Hi: 0x106D, Stride: 1},
+		{Lo: 0x1083, Hi: 0x1083, Stride: 1},
+		{Lo: 0x1087, Hi: 0x108C, Stride: 1},
+		{Lo: 0x108F, Hi: 0x108F, Stride: 1},
+		{Lo: 0x109A, Hi: 0x109C, Stride: 1},
+		{Lo: 0x1A61, Hi: 0x1A61, Stride: 1},
+		{Lo: 0x1A63, Hi: 0x1A64, Stride: 1},
+		{Lo: 0xAA7B, Hi: 0xAA7B, Stride: 1},
+		{Lo: 0xAA7D, Hi: 0xAA7D, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x11720, Hi: 0x11721, Stride: 1},
+	},
+}
+
+var extendedPictographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00A9, Stride: 1},
+		{Lo: 0x00AE, Hi: 0x00AE, Stride: 1},
+		{Lo: 0x203C, Hi: 0x203C, Stride: 1},
+		{Lo: 0x2049, Hi: 0x2049, Stride: 1},
+		{Lo: 0x2122, Hi: 0x2122, Stride: 1},
+		{Lo: 0x2139, Hi: 0x2139, Stride: 1},
+		{Lo: 0x2194, Hi: 0x2199, Stride: 1},
+		{Lo: 0x21A9, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x231B, Stride: 1},
+		{Lo: 0x2328, Hi: 0x2328, Stride: 1},
+		{Lo: 0x2388, Hi: 0x2388, Stride: 1},
+		{Lo: 0x23CF, Hi: 0x23CF, Stride: 1},
+		{Lo: 0x23E9, Hi: 0x23F3, Stride: 1},
+		{Lo: 0x23F8, Hi: 0x23FA, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25AB, Stride: 1},
+		{Lo: 0x25B6, Hi: 0x25B6, Stride: 1},
+		{Lo: 0x25C0, Hi: 0x25C0, Stride: 1},
+		{Lo: 0x25FB, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x2605, Stride: 1},
+		{Lo: 0x2607, Hi: 0x2612, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2685, Stride: 1},
+		{Lo: 0x2690, Hi: 0x2705, Stride: 1},
+		{Lo: 0x2708, Hi: 0x2712, Stride: 1},
+		{Lo: 0x2714, Hi: 0x2714, Stride: 1},
+		{Lo: 0x2716, Hi: 0x2716, Stride: 1},
+		{Lo: 0x271D, Hi: 0x271D, Stride: 1},
+		{Lo: 0x2721, Hi: 0x2721, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x2733, Hi: 0x2734, Stride: 1},
+		{Lo: 0x2744, Hi: 0x2744, Stride: 1},
+		{Lo: 0x2747, Hi: 0x2747, Stride: 1},
+		{Lo: 0x274C, Hi: 0x274C, Stride: 1},
+		{Lo: 0x274E, Hi: 0x274E, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2763, Hi: 0x2767, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27A1, Hi: 0x27A1, Stride: 1},
+		{Lo: 0x27B0, Hi: 0x27B0, Stride: 1},
+		{Lo: 0x27BF, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B07, Stride: 1},
+		{Lo: 0x2B1B, Hi: 0x2B1C, Stride: 1},
+		{Lo: 0x2B50, Hi: 0x2B50, Stride: 1},
+		{Lo: 0x2B55, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x3030, Stride: 1},
+		{Lo: 0x303D, Hi: 0x303D, Stride: 1},
+		{Lo: 0x3297, Hi: 0x3297, Stride: 1},
+		{Lo: 0x3299, Hi: 0x3299, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F0FF, Stride: 1},
+		{Lo: 0x1F10D, Hi: 0x1F10F, Stride: 1},
+		{Lo: 0x1F12F, Hi: 0x1F12F, Stride: 1},
+		{Lo: 0x1F16C, Hi: 0x1F171, Stride: 1},
+		{Lo: 0x1F17E, Hi: 0x1F17F, Stride: 1},
+		{Lo: 0x1F18E, Hi: 0x1F18E, Stride: 1},
+		{Lo: 0x1F191, Hi: 0x1F19A, Stride: 1},
+		{Lo: 0x1F1AD, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F201, Hi: 0x1F20F, Stride: 1},
+		{Lo: 0x1F21A, Hi: 0x1F21A, Stride: 1},
+		{Lo: 0x1F22F, Hi: 0x1F22F, Stride: 1},
+		{Lo: 0x1F232, Hi: 0x1F23A, Stride: 1},
+		{Lo: 0x1F23C, Hi: 0x1F23F, Stride: 1},
+		{Lo: 0x1F249, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1F53D, Stride: 1},
+		{Lo: 0x1F546, Hi: 0x1F64F, Stride: 1},
+		{Lo: 0x1F680, Hi: 0x1F6FF, Stride: 1},
+		{Lo: 0x1F774, Hi: 0x1F77F, Stride: 1},
+		{Lo: 0x1F7D5, Hi: 0x1F7FF, Stride: 1},
+		{Lo: 0x1F80C, Hi: 0x1F80F, Stride: 1},
+		{Lo: 0x1F848, Hi: 0x1F84F, Stride: 1},
+		{Lo: 0x1F85A, Hi: 0x1F85F, Stride: 1},
+		{Lo: 0x1F888, Hi: 0x1F88F, Stride: 1},
+		{Lo: 0x1F8AE, Hi: 0x1F8FF, Stride: 1},
+		{Lo: 0x1F90C, Hi: 0x1F93A, Stride: 1},
+		{Lo: 0x1F93C, Hi: 0x1F945, Stride: 1},
+		{Lo: 0x1F947, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
@@ -0,0 +1,57 @@
+// Package ruleset formats and parses sets of rules, bit flags that can be
+// combined with |, as comma separated lists of their names.
+package ruleset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Name names one rule of a set.
+type Name[R ~uint8] struct {
+	Rule R
+	Name string
+}
+
+// Format lists the names of the rules in r in the order of names, or
+// returns "none" if r is empty.
+func Format[R ~uint8](r R, names []Name[R]) string {
+	if r == 0 {
+		return "none"
+	}
+
+	var list []string
+	for _, n := range names {
+		if r&n.Rule != 0 {
+			list = append(list, n.Name)
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+// Parse is the inverse of Format: it reads a comma separated list of rule
+// names, or "all" for all or "none".
+func Parse[R ~uint8](s string, all R, names []Name[R]) (R, error) {
+	var r R
+	for _, name := range strings.Split(s, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "", "none":
+			continue
+		case "all":
+			r |= all
+			continue
+		}
+
+		found := false
+		for _, n := range names {
+			if n.Name == name {
+				r |= n.Rule
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown rule %q", name)
+		}
+	}
+	return r, nil
+}
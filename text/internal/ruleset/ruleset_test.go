@@ -0,0 +1,46 @@
+package ruleset_test
+
+import (
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/text/internal/ruleset"
+)
+
+type rules uint8
+
+var names = []ruleset.Name[rules]{
+	{Rule: 1, Name: "one"},
+	{Rule: 2, Name: "two"},
+	{Rule: 4, Name: "four"},
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		expected rules
+		err      bool
+	}{
+		"empty":   {input: "", expected: 0},
+		"none":    {input: "none", expected: 0},
+		"all":     {input: "all", expected: 7},
+		"list":    {input: "one, four", expected: 5},
+		"unknown": {input: "one,three", err: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ruleset.Parse(tc.input, 7, names)
+			if (err != nil) != tc.err {
+				t.Fatalf("Expected error %t, got %v", tc.err, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, got)
+			}
+			if !tc.err {
+				if back, _ := ruleset.Parse(ruleset.Format(got, names), 7, names); back != got {
+					t.Errorf("Expected %q to parse back to %d, got %d", ruleset.Format(got, names), got, back)
+				}
+			}
+		})
+	}
+}
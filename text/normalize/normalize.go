@@ -0,0 +1,104 @@
+package normalize
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/idiomat/goo11ynyt/text/internal/ruleset"
+)
+
+// Normalizer rewrites a word into the form used for comparison.
+type Normalizer interface {
+	Normalize(s string) string
+}
+
+// Func adapts an ordinary function to the Normalizer interface.
+type Func func(s string) string
+
+func (f Func) Normalize(s string) string {
+	return f(s)
+}
+
+// Rules is a set of normalization steps that can be combined with |.
+type Rules uint8
+
+const (
+	FoldCase Rules = 1 << iota
+	StripPunctuation
+	RemoveDiacritics
+	IgnoreWhitespace
+)
+
+// All enables every rule, which is what phrase palindromes such as
+// "Step on no pets!" need.
+const All = FoldCase | StripPunctuation | RemoveDiacritics | IgnoreWhitespace
+
+func (r Rules) Normalize(s string) string {
+	if r == 0 {
+		return s
+	}
+
+	var buf [4]rune
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, c := range s {
+		for _, c := range r.AppendRune(buf[:0], c) {
+			if c < utf8.RuneSelf {
+				sb.WriteByte(byte(c))
+				continue
+			}
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// AppendRune appends the normalized form of c, which may be empty or span
+// several runes, to dst and returns the extended slice. It lets callers keep
+// track of where each normalized rune came from.
+func (r Rules) AppendRune(dst []rune, c rune) []rune {
+	if r&RemoveDiacritics != 0 {
+		if d, ok := decompositions[c]; ok {
+			for _, c := range d {
+				dst = r.AppendRune(dst, c)
+			}
+			return dst
+		}
+		if unicode.Is(unicode.Mn, c) {
+			return dst
+		}
+	}
+	if r&StripPunctuation != 0 && unicode.IsPunct(c) {
+		return dst
+	}
+	if r&IgnoreWhitespace != 0 && unicode.IsSpace(c) {
+		return dst
+	}
+	if r&FoldCase != 0 {
+		c = unicode.ToLower(c)
+	}
+	return append(dst, c)
+}
+
+var ruleNames = []ruleset.Name[Rules]{
+	{Rule: FoldCase, Name: "case"},
+	{Rule: StripPunctuation, Name: "punct"},
+	{Rule: RemoveDiacritics, Name: "diacritics"},
+	{Rule: IgnoreWhitespace, Name: "space"},
+}
+
+func (r Rules) String() string {
+	return ruleset.Format(r, ruleNames)
+}
+
+// ParseRules is the inverse of Rules.String: it reads a comma separated list
+// of rule names, or "all" or "none".
+func ParseRules(s string) (Rules, error) {
+	r, err := ruleset.Parse(s, All, ruleNames)
+	if err != nil {
+		return 0, fmt.Errorf("normalize: %w", err)
+	}
+	return r, nil
+}
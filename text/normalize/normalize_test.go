@@ -0,0 +1,157 @@
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/text/normalize"
+)
+
+func TestRulesNormalize(t *testing.T) {
+	tests := map[string]struct {
+		rules    normalize.Rules
+		input    string
+		expected string
+	}{
+		"none": {
+			rules:    0,
+			input:    "Madam, I'm Adam",
+			expected: "Madam, I'm Adam",
+		},
+		"fold case": {
+			rules:    normalize.FoldCase,
+			input:    "Level",
+			expected: "level",
+		},
+		"strip punctuation": {
+			rules:    normalize.StripPunctuation,
+			input:    "Madam,",
+			expected: "Madam",
+		},
+		"remove diacritics": {
+			rules:    normalize.RemoveDiacritics,
+			input:    "été Ångström",
+			expected: "ete Angstrom",
+		},
+		"remove recursive diacritics": {
+			rules:    normalize.RemoveDiacritics,
+			input:    "Ộ ǖ",
+			expected: "O u",
+		},
+		"remove combining marks": {
+			rules:    normalize.RemoveDiacritics,
+			input:    "été",
+			expected: "ete",
+		},
+		"ignore whitespace": {
+			rules:    normalize.IgnoreWhitespace,
+			input:    "step on\tno\npets",
+			expected: "steponnopets",
+		},
+		"all": {
+			rules:    normalize.All,
+			input:    "Step on no pets!",
+			expected: "steponnopets",
+		},
+		"greek": {
+			rules:    normalize.All,
+			input:    "Ἀθῆναι",
+			expected: "αθηναι",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.rules.Normalize(tc.input); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRulesString(t *testing.T) {
+	if got := normalize.All.String(); got != "case,punct,diacritics,space" {
+		t.Errorf("Expected %q, got %q", "case,punct,diacritics,space", got)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expected  normalize.Rules
+		expectErr bool
+	}{
+		"empty": {
+			input:    "",
+			expected: 0,
+		},
+		"none": {
+			input:    "none",
+			expected: 0,
+		},
+		"all": {
+			input:    "all",
+			expected: normalize.All,
+		},
+		"list": {
+			input:    "case, punct",
+			expected: normalize.FoldCase | normalize.StripPunctuation,
+		},
+		"round trip": {
+			input:    normalize.All.String(),
+			expected: normalize.All,
+		},
+		"unknown": {
+			input:     "case,accents",
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := normalize.ParseRules(tc.input)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRulesAppendRune(t *testing.T) {
+	tests := map[string]struct {
+		rules    normalize.Rules
+		input    rune
+		expected string
+	}{
+		"kept": {
+			rules:    normalize.All,
+			input:    'A',
+			expected: "a",
+		},
+		"dropped": {
+			rules:    normalize.All,
+			input:    '!',
+			expected: "",
+		},
+		"folded": {
+			rules:    normalize.FoldCase,
+			input:    'É',
+			expected: "é",
+		},
+		"diacritic removed": {
+			rules:    normalize.RemoveDiacritics,
+			input:    'É',
+			expected: "E",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := string(tc.rules.AppendRune(nil, tc.input)); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
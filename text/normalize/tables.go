@@ -0,0 +1,812 @@
+package normalize
+
+// decompositions maps precomposed runes to their canonical decomposition as
+// listed in UnicodeData.txt. Only the Latin, Greek and Cyrillic blocks are
+// covered; decompositions may themselves contain decomposable runes, so
+// callers apply the table recursively.
+var decompositions = map[rune][]rune{
+	0x00C0: {0x0041, 0x0300}, // À
+	0x00C1: {0x0041, 0x0301}, // Á
+	0x00C2: {0x0041, 0x0302}, // Â
+	0x00C3: {0x0041, 0x0303}, // Ã
+	0x00C4: {0x0041, 0x0308}, // Ä
+	0x00C5: {0x0041, 0x030A}, // Å
+	0x00C7: {0x0043, 0x0327}, // Ç
+	0x00C8: {0x0045, 0x0300}, // È
+	0x00C9: {0x0045, 0x0301}, // É
+	0x00CA: {0x0045, 0x0302}, // Ê
+	0x00CB: {0x0045, 0x0308}, // Ë
+	0x00CC: {0x0049, 0x0300}, // Ì
+	0x00CD: {0x0049, 0x0301}, // Í
+	0x00CE: {0x0049, 0x0302}, // Î
+	0x00CF: {0x0049, 0x0308}, // Ï
+	0x00D1: {0x004E, 0x0303}, // Ñ
+	0x00D2: {0x004F, 0x0300}, // Ò
+	0x00D3: {0x004F, 0x0301}, // Ó
+	0x00D4: {0x004F, 0x0302}, // Ô
+	0x00D5: {0x004F, 0x0303}, // Õ
+	0x00D6: {0x004F, 0x0308}, // Ö
+	0x00D9: {0x0055, 0x0300}, // Ù
+	0x00DA: {0x0055, 0x0301}, // Ú
+	0x00DB: {0x0055, 0x0302}, // Û
+	0x00DC: {0x0055, 0x0308}, // Ü
+	0x00DD: {0x0059, 0x0301}, // Ý
+	0x00E0: {0x0061, 0x0300}, // à
+	0x00E1: {0x0061, 0x0301}, // á
+	0x00E2: {0x0061, 0x0302}, // â
+	0x00E3: {0x0061, 0x0303}, // ã
+	0x00E4: {0x0061, 0x0308}, // ä
+	0x00E5: {0x0061, 0x030A}, // å
+	0x00E7: {0x0063, 0x0327}, // ç
+	0x00E8: {0x0065, 0x0300}, // è
+	0x00E9: {0x0065, 0x0301}, // é
+	0x00EA: {0x0065, 0x0302}, // ê
+	0x00EB: {0x0065, 0x0308}, // ë
+	0x00EC: {0x0069, 0x0300}, // ì
+	0x00ED: {0x0069, 0x0301}, // í
+	0x00EE: {0x0069, 0x0302}, // î
+	0x00EF: {0x0069, 0x0308}, // ï
+	0x00F1: {0x006E, 0x0303}, // ñ
+	0x00F2: {0x006F, 0x0300}, // ò
+	0x00F3: {0x006F, 0x0301}, // ó
+	0x00F4: {0x006F, 0x0302}, // ô
+	0x00F5: {0x006F, 0x0303}, // õ
+	0x00F6: {0x006F, 0x0308}, // ö
+	0x00F9: {0x0075, 0x0300}, // ù
+	0x00FA: {0x0075, 0x0301}, // ú
+	0x00FB: {0x0075, 0x0302}, // û
+	0x00FC: {0x0075, 0x0308}, // ü
+	0x00FD: {0x0079, 0x0301}, // ý
+	0x00FF: {0x0079, 0x0308}, // ÿ
+	0x0100: {0x0041, 0x0304}, // Ā
+	0x0101: {0x0061, 0x0304}, // ā
+	0x0102: {0x0041, 0x0306}, // Ă
+	0x0103: {0x0061, 0x0306}, // ă
+	0x0104: {0x0041, 0x0328}, // Ą
+	0x0105: {0x0061, 0x0328}, // ą
+	0x0106: {0x0043, 0x0301}, // Ć
+	0x0107: {0x0063, 0x0301}, // ć
+	0x0108: {0x0043, 0x0302}, // Ĉ
+	0x0109: {0x0063, 0x0302}, // ĉ
+	0x010A: {0x0043, 0x0307}, // Ċ
+	0x010B: {0x0063, 0x0307}, // ċ
+	0x010C: {0x0043, 0x030C}, // Č
+	0x010D: {0x0063, 0x030C}, // č
+	0x010E: {0x0044, 0x030C}, // Ď
+	0x010F: {0x0064, 0x030C}, // ď
+	0x0112: {0x0045, 0x0304}, // Ē
+	0x0113: {0x0065, 0x0304}, // ē
+	0x0114: {0x0045, 0x0306}, // Ĕ
+	0x0115: {0x0065, 0x0306}, // ĕ
+	0x0116: {0x0045, 0x0307}, // Ė
+	0x0117: {0x0065, 0x0307}, // ė
+	0x0118: {0x0045, 0x0328}, // Ę
+	0x0119: {0x0065, 0x0328}, // ę
+	0x011A: {0x0045, 0x030C}, // Ě
+	0x011B: {0x0065, 0x030C}, // ě
+	0x011C: {0x0047, 0x0302}, // Ĝ
+	0x011D: {0x0067, 0x0302}, // ĝ
+	0x011E: {0x0047, 0x0306}, // Ğ
+	0x011F: {0x0067, 0x0306}, // ğ
+	0x0120: {0x0047, 0x0307}, // Ġ
+	0x0121: {0x0067, 0x0307}, // ġ
+	0x0122: {0x0047, 0x0327}, // Ģ
+	0x0123: {0x0067, 0x0327}, // ģ
+	0x0124: {0x0048, 0x0302}, // Ĥ
+	0x0125: {0x0068, 0x0302}, // ĥ
+	0x0128: {0x0049, 0x0303}, // Ĩ
+	0x0129: {0x0069, 0x0303}, // ĩ
+	0x012A: {0x0049, 0x0304}, // Ī
+	0x012B: {0x0069, 0x0304}, // ī
+	0x012C: {0x0049, 0x0306}, // Ĭ
+	0x012D: {0x0069, 0x0306}, // ĭ
+	0x012E: {0x0049, 0x0328}, // Į
+	0x012F: {0x0069, 0x0328}, // į
+	0x0130: {0x0049, 0x0307}, // İ
+	0x0134: {0x004A, 0x0302}, // Ĵ
+	0x0135: {0x006A, 0x0302}, // ĵ
+	0x0136: {0x004B, 0x0327}, // Ķ
+	0x0137: {0x006B, 0x0327}, // ķ
+	0x0139: {0x004C, 0x0301}, // Ĺ
+	0x013A: {0x006C, 0x0301}, // ĺ
+	0x013B: {0x004C, 0x0327}, // Ļ
+	0x013C: {0x006C, 0x0327}, // ļ
+	0x013D: {0x004C, 0x030C}, // Ľ
+	0x013E: {0x006C, 0x030C}, // ľ
+	0x0143: {0x004E, 0x0301}, // Ń
+	0x0144: {0x006E, 0x0301}, // ń
+	0x0145: {0x004E, 0x0327}, // Ņ
+	0x0146: {0x006E, 0x0327}, // ņ
+	0x0147: {0x004E, 0x030C}, // Ň
+	0x0148: {0x006E, 0x030C}, // ň
+	0x014C: {0x004F, 0x0304}, // Ō
+	0x014D: {0x006F, 0x0304}, // ō
+	0x014E: {0x004F, 0x0306}, // Ŏ
+	0x014F: {0x006F, 0x0306}, // ŏ
+	0x0150: {0x004F, 0x030B}, // Ő
+	0x0151: {0x006F, 0x030B}, // ő
+	0x0154: {0x0052, 0x0301}, // Ŕ
+	0x0155: {0x0072, 0x0301}, // ŕ
+	0x0156: {0x0052, 0x0327}, // Ŗ
+	0x0157: {0x0072, 0x0327}, // ŗ
+	0x0158: {0x0052, 0x030C}, // Ř
+	0x0159: {0x0072, 0x030C}, // ř
+	0x015A: {0x0053, 0x0301}, // Ś
+	0x015B: {0x0073, 0x0301}, // ś
+	0x015C: {0x0053, 0x0302}, // Ŝ
+	0x015D: {0x0073, 0x0302}, // ŝ
+	0x015E: {0x0053, 0x0327}, // Ş
+	0x015F: {0x0073, 0x0327}, // ş
+	0x0160: {0x0053, 0x030C}, // Š
+	0x0161: {0x0073, 0x030C}, // š
+	0x0162: {0x0054, 0x0327}, // Ţ
+	0x0163: {0x0074, 0x0327}, // ţ
+	0x0164: {0x0054, 0x030C}, // Ť
+	0x0165: {0x0074, 0x030C}, // ť
+	0x0168: {0x0055, 0x0303}, // Ũ
+	0x0169: {0x0075, 0x0303}, // ũ
+	0x016A: {0x0055, 0x0304}, // Ū
+	0x016B: {0x0075, 0x0304}, // ū
+	0x016C: {0x0055, 0x0306}, // Ŭ
+	0x016D: {0x0075, 0x0306}, // ŭ
+	0x016E: {0x0055, 0x030A}, // Ů
+	0x016F: {0x0075, 0x030A}, // ů
+	0x0170: {0x0055, 0x030B}, // Ű
+	0x0171: {0x0075, 0x030B}, // ű
+	0x0172: {0x0055, 0x0328}, // Ų
+	0x0173: {0x0075, 0x0328}, // ų
+	0x0174: {0x0057, 0x0302}, // Ŵ
+	0x0175: {0x0077, 0x0302}, // ŵ
+	0x0176: {0x0059, 0x0302}, // Ŷ
+	0x0177: {0x0079, 0x0302}, // ŷ
+	0x0178: {0x0059, 0x0308}, // Ÿ
+	0x0179: {0x005A, 0x0301}, // Ź
+	0x017A: {0x007A, 0x0301}, // ź
+	0x017B: {0x005A, 0x0307}, // Ż
+	0x017C: {0x007A, 0x0307}, // ż
+	0x017D: {0x005A, 0x030C}, // Ž
+	0x017E: {0x007A, 0x030C}, // ž
+	0x01A0: {0x004F, 0x031B}, // Ơ
+	0x01A1: {0x006F, 0x031B}, // ơ
+	0x01AF: {0x0055, 0x031B}, // Ư
+	0x01B0: {0x0075, 0x031B}, // ư
+	0x01CD: {0x0041, 0x030C}, // Ǎ
+	0x01CE: {0x0061, 0x030C}, // ǎ
+	0x01CF: {0x0049, 0x030C}, // Ǐ
+	0x01D0: {0x0069, 0x030C}, // ǐ
+	0x01D1: {0x004F, 0x030C}, // Ǒ
+	0x01D2: {0x006F, 0x030C}, // ǒ
+	0x01D3: {0x0055, 0x030C}, // Ǔ
+	0x01D4: {0x0075, 0x030C}, // ǔ
+	0x01D5: {0x00DC, 0x0304}, // Ǖ
+	0x01D6: {0x00FC, 0x0304}, // ǖ
+	0x01D7: {0x00DC, 0x0301}, // Ǘ
+	0x01D8: {0x00FC, 0x0301}, // ǘ
+	0x01D9: {0x00DC, 0x030C}, // Ǚ
+	0x01DA: {0x00FC, 0x030C}, // ǚ
+	0x01DB: {0x00DC, 0x0300}, // Ǜ
+	0x01DC: {0x00FC, 0x0300}, // ǜ
+	0x01DE: {0x00C4, 0x0304}, // Ǟ
+	0x01DF: {0x00E4, 0x0304}, // ǟ
+	0x01E0: {0x0226, 0x0304}, // Ǡ
+	0x01E1: {0x0227, 0x0304}, // ǡ
+	0x01E2: {0x00C6, 0x0304}, // Ǣ
+	0x01E3: {0x00E6, 0x0304}, // ǣ
+	0x01E6: {0x0047, 0x030C}, // Ǧ
+	0x01E7: {0x0067, 0x030C}, // ǧ
+	0x01E8: {0x004B, 0x030C}, // Ǩ
+	0x01E9: {0x006B, 0x030C}, // ǩ
+	0x01EA: {0x004F, 0x0328}, // Ǫ
+	0x01EB: {0x006F, 0x0328}, // ǫ
+	0x01EC: {0x01EA, 0x0304}, // Ǭ
+	0x01ED: {0x01EB, 0x0304}, // ǭ
+	0x01EE: {0x01B7, 0x030C}, // Ǯ
+	0x01EF: {0x0292, 0x030C}, // ǯ
+	0x01F0: {0x006A, 0x030C}, // ǰ
+	0x01F4: {0x0047, 0x0301}, // Ǵ
+	0x01F5: {0x0067, 0x0301}, // ǵ
+	0x01F8: {0x004E, 0x0300}, // Ǹ
+	0x01F9: {0x006E, 0x0300}, // ǹ
+	0x01FA: {0x00C5, 0x0301}, // Ǻ
+	0x01FB: {0x00E5, 0x0301}, // ǻ
+	0x01FC: {0x00C6, 0x0301}, // Ǽ
+	0x01FD: {0x00E6, 0x0301}, // ǽ
+	0x01FE: {0x00D8, 0x0301}, // Ǿ
+	0x01FF: {0x00F8, 0x0301}, // ǿ
+	0x0200: {0x0041, 0x030F}, // Ȁ
+	0x0201: {0x0061, 0x030F}, // ȁ
+	0x0202: {0x0041, 0x0311}, // Ȃ
+	0x0203: {0x0061, 0x0311}, // ȃ
+	0x0204: {0x0045, 0x030F}, // Ȅ
+	0x0205: {0x0065, 0x030F}, // ȅ
+	0x0206: {0x0045, 0x0311}, // Ȇ
+	0x0207: {0x0065, 0x0311}, // ȇ
+	0x0208: {0x0049, 0x030F}, // Ȉ
+	0x0209: {0x0069, 0x030F}, // ȉ
+	0x020A: {0x0049, 0x0311}, // Ȋ
+	0x020B: {0x0069, 0x0311}, // ȋ
+	0x020C: {0x004F, 0x030F}, // Ȍ
+	0x020D: {0x006F, 0x030F}, // ȍ
+	0x020E: {0x004F, 0x0311}, // Ȏ
+	0x020F: {0x006F, 0x0311}, // ȏ
+	0x0210: {0x0052, 0x030F}, // Ȑ
+	0x0211: {0x0072, 0x030F}, // ȑ
+	0x0212: {0x0052, 0x0311}, // Ȓ
+	0x0213: {0x0072, 0x0311}, // ȓ
+	0x0214: {0x0055, 0x030F}, // Ȕ
+	0x0215: {0x0075, 0x030F}, // ȕ
+	0x0216: {0x0055, 0x0311}, // Ȗ
+	0x0217: {0x0075, 0x0311}, // ȗ
+	0x0218: {0x0053, 0x0326}, // Ș
+	0x0219: {0x0073, 0x0326}, // ș
+	0x021A: {0x0054, 0x0326}, // Ț
+	0x021B: {0x0074, 0x0326}, // ț
+	0x021E: {0x0048, 0x030C}, // Ȟ
+	0x021F: {0x0068, 0x030C}, // ȟ
+	0x0226: {0x0041, 0x0307}, // Ȧ
+	0x0227: {0x0061, 0x0307}, // ȧ
+	0x0228: {0x0045, 0x0327}, // Ȩ
+	0x0229: {0x0065, 0x0327}, // ȩ
+	0x022A: {0x00D6, 0x0304}, // Ȫ
+	0x022B: {0x00F6, 0x0304}, // ȫ
+	0x022C: {0x00D5, 0x0304}, // Ȭ
+	0x022D: {0x00F5, 0x0304}, // ȭ
+	0x022E: {0x004F, 0x0307}, // Ȯ
+	0x022F: {0x006F, 0x0307}, // ȯ
+	0x0230: {0x022E, 0x0304}, // Ȱ
+	0x0231: {0x022F, 0x0304}, // ȱ
+	0x0232: {0x0059, 0x0304}, // Ȳ
+	0x0233: {0x0079, 0x0304}, // ȳ
+	0x1E00: {0x0041, 0x0325}, // Ḁ
+	0x1E01: {0x0061, 0x0325}, // ḁ
+	0x1E02: {0x0042, 0x0307}, // Ḃ
+	0x1E03: {0x0062, 0x0307}, // ḃ
+	0x1E04: {0x0042, 0x0323}, // Ḅ
+	0x1E05: {0x0062, 0x0323}, // ḅ
+	0x1E06: {0x0042, 0x0331}, // Ḇ
+	0x1E07: {0x0062, 0x0331}, // ḇ
+	0x1E08: {0x00C7, 0x0301}, // Ḉ
+	0x1E09: {0x00E7, 0x0301}, // ḉ
+	0x1E0A: {0x0044, 0x0307}, // Ḋ
+	0x1E0B: {0x0064, 0x0307}, // ḋ
+	0x1E0C: {0x0044, 0x0323}, // Ḍ
+	0x1E0D: {0x0064, 0x0323}, // ḍ
+	0x1E0E: {0x0044, 0x0331}, // Ḏ
+	0x1E0F: {0x0064, 0x0331}, // ḏ
+	0x1E10: {0x0044, 0x0327}, // Ḑ
+	0x1E11: {0x0064, 0x0327}, // ḑ
+	0x1E12: {0x0044, 0x032D}, // Ḓ
+	0x1E13: {0x0064, 0x032D}, // ḓ
+	0x1E14: {0x0112, 0x0300}, // Ḕ
+	0x1E15: {0x0113, 0x0300}, // ḕ
+	0x1E16: {0x0112, 0x0301}, // Ḗ
+	0x1E17: {0x0113, 0x0301}, // ḗ
+	0x1E18: {0x0045, 0x032D}, // Ḙ
+	0x1E19: {0x0065, 0x032D}, // ḙ
+	0x1E1A: {0x0045, 0x0330}, // Ḛ
+	0x1E1B: {0x0065, 0x0330}, // ḛ
+	0x1E1C: {0x0228, 0x0306}, // Ḝ
+	0x1E1D: {0x0229, 0x0306}, // ḝ
+	0x1E1E: {0x0046, 0x0307}, // Ḟ
+	0x1E1F: {0x0066, 0x0307}, // ḟ
+	0x1E20: {0x0047, 0x0304}, // Ḡ
+	0x1E21: {0x0067, 0x0304}, // ḡ
+	0x1E22: {0x0048, 0x0307}, // Ḣ
+	0x1E23: {0x0068, 0x0307}, // ḣ
+	0x1E24: {0x0048, 0x0323}, // Ḥ
+	0x1E25: {0x0068, 0x0323}, // ḥ
+	0x1E26: {0x0048, 0x0308}, // Ḧ
+	0x1E27: {0x0068, 0x0308}, // ḧ
+	0x1E28: {0x0048, 0x0327}, // Ḩ
+	0x1E29: {0x0068, 0x0327}, // ḩ
+	0x1E2A: {0x0048, 0x032E}, // Ḫ
+	0x1E2B: {0x0068, 0x032E}, // ḫ
+	0x1E2C: {0x0049, 0x0330}, // Ḭ
+	0x1E2D: {0x0069, 0x0330}, // ḭ
+	0x1E2E: {0x00CF, 0x0301}, // Ḯ
+	0x1E2F: {0x00EF, 0x0301}, // ḯ
+	0x1E30: {0x004B, 0x0301}, // Ḱ
+	0x1E31: {0x006B, 0x0301}, // ḱ
+	0x1E32: {0x004B, 0x0323}, // Ḳ
+	0x1E33: {0x006B, 0x0323}, // ḳ
+	0x1E34: {0x004B, 0x0331}, // Ḵ
+	0x1E35: {0x006B, 0x0331}, // ḵ
+	0x1E36: {0x004C, 0x0323}, // Ḷ
+	0x1E37: {0x006C, 0x0323}, // ḷ
+	0x1E38: {0x1E36, 0x0304}, // Ḹ
+	0x1E39: {0x1E37, 0x0304}, // ḹ
+	0x1E3A: {0x004C, 0x0331}, // Ḻ
+	0x1E3B: {0x006C, 0x0331}, // ḻ
+	0x1E3C: {0x004C, 0x032D}, // Ḽ
+	0x1E3D: {0x006C, 0x032D}, // ḽ
+	0x1E3E: {0x004D, 0x0301}, // Ḿ
+	0x1E3F: {0x006D, 0x0301}, // ḿ
+	0x1E40: {0x004D, 0x0307}, // Ṁ
+	0x1E41: {0x006D, 0x0307}, // ṁ
+	0x1E42: {0x004D, 0x0323}, // Ṃ
+	0x1E43: {0x006D, 0x0323}, // ṃ
+	0x1E44: {0x004E, 0x0307}, // Ṅ
+	0x1E45: {0x006E, 0x0307}, // ṅ
+	0x1E46: {0x004E, 0x0323}, // Ṇ
+	0x1E47: {0x006E, 0x0323}, // ṇ
+	0x1E48: {0x004E, 0x0331}, // Ṉ
+	0x1E49: {0x006E, 0x0331}, // ṉ
+	0x1E4A: {0x004E, 0x032D}, // Ṋ
+	0x1E4B: {0x006E, 0x032D}, // ṋ
+	0x1E4C: {0x00D5, 0x0301}, // Ṍ
+	0x1E4D: {0x00F5, 0x0301}, // ṍ
+	0x1E4E: {0x00D5, 0x0308}, // Ṏ
+	0x1E4F: {0x00F5, 0x0308}, // ṏ
+	0x1E50: {0x014C, 0x0300}, // Ṑ
+	0x1E51: {0x014D, 0x0300}, // ṑ
+	0x1E52: {0x014C, 0x0301}, // Ṓ
+	0x1E53: {0x014D, 0x0301}, // ṓ
+	0x1E54: {0x0050, 0x0301}, // Ṕ
+	0x1E55: {0x0070, 0x0301}, // ṕ
+	0x1E56: {0x0050, 0x0307}, // Ṗ
+	0x1E57: {0x0070, 0x0307}, // ṗ
+	0x1E58: {0x0052, 0x0307}, // Ṙ
+	0x1E59: {0x0072, 0x0307}, // ṙ
+	0x1E5A: {0x0052, 0x0323}, // Ṛ
+	0x1E5B: {0x0072, 0x0323}, // ṛ
+	0x1E5C: {0x1E5A, 0x0304}, // Ṝ
+	0x1E5D: {0x1E5B, 0x0304}, // ṝ
+	0x1E5E: {0x0052, 0x0331}, // Ṟ
+	0x1E5F: {0x0072, 0x0331}, // ṟ
+	0x1E60: {0x0053, 0x0307}, // Ṡ
+	0x1E61: {0x0073, 0x0307}, // ṡ
+	0x1E62: {0x0053, 0x0323}, // Ṣ
+	0x1E63: {0x0073, 0x0323}, // ṣ
+	0x1E64: {0x015A, 0x0307}, // Ṥ
+	0x1E65: {0x015B, 0x0307}, // ṥ
+	0x1E66: {0x0160, 0x0307}, // Ṧ
+	0x1E67: {0x0161, 0x0307}, // ṧ
+	0x1E68: {0x1E62, 0x0307}, // Ṩ
+	0x1E69: {0x1E63, 0x0307}, // ṩ
+	0x1E6A: {0x0054, 0x0307}, // Ṫ
+	0x1E6B: {0x0074, 0x0307}, // ṫ
+	0x1E6C: {0x0054, 0x0323}, // Ṭ
+	0x1E6D: {0x0074, 0x0323}, // ṭ
+	0x1E6E: {0x0054, 0x0331}, // Ṯ
+	0x1E6F: {0x0074, 0x0331}, // ṯ
+	0x1E70: {0x0054, 0x032D}, // Ṱ
+	0x1E71: {0x0074, 0x032D}, // ṱ
+	0x1E72: {0x0055, 0x0324}, // Ṳ
+	0x1E73: {0x0075, 0x0324}, // ṳ
+	0x1E74: {0x0055, 0x0330}, // Ṵ
+	0x1E75: {0x0075, 0x0330}, // ṵ
+	0x1E76: {0x0055, 0x032D}, // Ṷ
+	0x1E77: {0x0075, 0x032D}, // ṷ
+	0x1E78: {0x0168, 0x0301}, // Ṹ
+	0x1E79: {0x0169, 0x0301}, // ṹ
+	0x1E7A: {0x016A, 0x0308}, // Ṻ
+	0x1E7B: {0x016B, 0x0308}, // ṻ
+	0x1E7C: {0x0056, 0x0303}, // Ṽ
+	0x1E7D: {0x0076, 0x0303}, // ṽ
+	0x1E7E: {0x0056, 0x0323}, // Ṿ
+	0x1E7F: {0x0076, 0x0323}, // ṿ
+	0x1E80: {0x0057, 0x0300}, // Ẁ
+	0x1E81: {0x0077, 0x0300}, // ẁ
+	0x1E82: {0x0057, 0x0301}, // Ẃ
+	0x1E83: {0x0077, 0x0301}, // ẃ
+	0x1E84: {0x0057, 0x0308}, // Ẅ
+	0x1E85: {0x0077, 0x0308}, // ẅ
+	0x1E86: {0x0057, 0x0307}, // Ẇ
+	0x1E87: {0x0077, 0x0307}, // ẇ
+	0x1E88: {0x0057, 0x0323}, // Ẉ
+	0x1E89: {0x0077, 0x0323}, // ẉ
+	0x1E8A: {0x0058, 0x0307}, // Ẋ
+	0x1E8B: {0x0078, 0x0307}, // ẋ
+	0x1E8C: {0x0058, 0x0308}, // Ẍ
+	0x1E8D: {0x0078, 0x0308}, // ẍ
+	0x1E8E: {0x0059, 0x0307}, // Ẏ
+	0x1E8F: {0x0079, 0x0307}, // ẏ
+	0x1E90: {0x005A, 0x0302}, // Ẑ
+	0x1E91: {0x007A, 0x0302}, // ẑ
+	0x1E92: {0x005A, 0x0323}, // Ẓ
+	0x1E93: {0x007A, 0x0323}, // ẓ
+	0x1E94: {0x005A, 0x0331}, // Ẕ
+	0x1E95: {0x007A, 0x0331}, // ẕ
+	0x1E96: {0x0068, 0x0331}, // ẖ
+	0x1E97: {0x0074, 0x0308}, // ẗ
+	0x1E98: {0x0077, 0x030A}, // ẘ
+	0x1E99: {0x0079, 0x030A}, // ẙ
+	0x1E9B: {0x017F, 0x0307}, // ẛ
+	0x1EA0: {0x0041, 0x0323}, // Ạ
+	0x1EA1: {0x0061, 0x0323}, // ạ
+	0x1EA2: {0x0041, 0x0309}, // Ả
+	0x1EA3: {0x0061, 0x0309}, // ả
+	0x1EA4: {0x00C2, 0x0301}, // Ấ
+	0x1EA5: {0x00E2, 0x0301}, // ấ
+	0x1EA6: {0x00C2, 0x0300}, // Ầ
+	0x1EA7: {0x00E2, 0x0300}, // ầ
+	0x1EA8: {0x00C2, 0x0309}, // Ẩ
+	0x1EA9: {0x00E2, 0x0309}, // ẩ
+	0x1EAA: {0x00C2, 0x0303}, // Ẫ
+	0x1EAB: {0x00E2, 0x0303}, // ẫ
+	0x1EAC: {0x1EA0, 0x0302}, // Ậ
+	0x1EAD: {0x1EA1, 0x0302}, // ậ
+	0x1EAE: {0x0102, 0x0301}, // Ắ
+	0x1EAF: {0x0103, 0x0301}, // ắ
+	0x1EB0: {0x0102, 0x0300}, // Ằ
+	0x1EB1: {0x0103, 0x0300}, // ằ
+	0x1EB2: {0x0102, 0x0309}, // Ẳ
+	0x1EB3: {0x0103, 0x0309}, // ẳ
+	0x1EB4: {0x0102, 0x0303}, // Ẵ
+	0x1EB5: {0x0103, 0x0303}, // ẵ
+	0x1EB6: {0x1EA0, 0x0306}, // Ặ
+	0x1EB7: {0x1EA1, 0x0306}, // ặ
+	0x1EB8: {0x0045, 0x0323}, // Ẹ
+	0x1EB9: {0x0065, 0x0323}, // ẹ
+	0x1EBA: {0x0045, 0x0309}, // Ẻ
+	0x1EBB: {0x0065, 0x0309}, // ẻ
+	0x1EBC: {0x0045, 0x0303}, // Ẽ
+	0x1EBD: {0x0065, 0x0303}, // ẽ
+	0x1EBE: {0x00CA, 0x0301}, // Ế
+	0x1EBF: {0x00EA, 0x0301}, // ế
+	0x1EC0: {0x00CA, 0x0300}, // Ề
+	0x1EC1: {0x00EA, 0x0300}, // ề
+	0x1EC2: {0x00CA, 0x0309}, // Ể
+	0x1EC3: {0x00EA, 0x0309}, // ể
+	0x1EC4: {0x00CA, 0x0303}, // Ễ
+	0x1EC5: {0x00EA, 0x0303}, // ễ
+	0x1EC6: {0x1EB8, 0x0302}, // Ệ
+	0x1EC7: {0x1EB9, 0x0302}, // ệ
+	0x1EC8: {0x0049, 0x0309}, // Ỉ
+	0x1EC9: {0x0069, 0x0309}, // ỉ
+	0x1ECA: {0x0049, 0x0323}, // Ị
+	0x1ECB: {0x0069, 0x0323}, // ị
+	0x1ECC: {0x004F, 0x0323}, // Ọ
+	0x1ECD: {0x006F, 0x0323}, // ọ
+	0x1ECE: {0x004F, 0x0309}, // Ỏ
+	0x1ECF: {0x006F, 0x0309}, // ỏ
+	0x1ED0: {0x00D4, 0x0301}, // Ố
+	0x1ED1: {0x00F4, 0x0301}, // ố
+	0x1ED2: {0x00D4, 0x0300}, // Ồ
+	0x1ED3: {0x00F4, 0x0300}, // ồ
+	0x1ED4: {0x00D4, 0x0309}, // Ổ
+	0x1ED5: {0x00F4, 0x0309}, // ổ
+	0x1ED6: {0x00D4, 0x0303}, // Ỗ
+	0x1ED7: {0x00F4, 0x0303}, // ỗ
+	0x1ED8: {0x1ECC, 0x0302}, // Ộ
+	0x1ED9: {0x1ECD, 0x0302}, // ộ
+	0x1EDA: {0x01A0, 0x0301}, // Ớ
+	0x1EDB: {0x01A1, 0x0301}, // ớ
+	0x1EDC: {0x01A0, 0x0300}, // Ờ
+	0x1EDD: {0x01A1, 0x0300}, // ờ
+	0x1EDE: {0x01A0, 0x0309}, // Ở
+	0x1EDF: {0x01A1, 0x0309}, // ở
+	0x1EE0: {0x01A0, 0x0303}, // Ỡ
+	0x1EE1: {0x01A1, 0x0303}, // ỡ
+	0x1EE2: {0x01A0, 0x0323}, // Ợ
+	0x1EE3: {0x01A1, 0x0323}, // ợ
+	0x1EE4: {0x0055, 0x0323}, // Ụ
+	0x1EE5: {0x0075, 0x0323}, // ụ
+	0x1EE6: {0x0055, 0x0309}, // Ủ
+	0x1EE7: {0x0075, 0x0309}, // ủ
+	0x1EE8: {0x01AF, 0x0301}, // Ứ
+	0x1EE9: {0x01B0, 0x0301}, // ứ
+	0x1EEA: {0x01AF, 0x0300}, // Ừ
+	0x1EEB: {0x01B0, 0x0300}, // ừ
+	0x1EEC: {0x01AF, 0x0309}, // Ử
+	0x1EED: {0x01B0, 0x0309}, // ử
+	0x1EEE: {0x01AF, 0x0303}, // Ữ
+	0x1EEF: {0x01B0, 0x0303}, // ữ
+	0x1EF0: {0x01AF, 0x0323}, // Ự
+	0x1EF1: {0x01B0, 0x0323}, // ự
+	0x1EF2: {0x0059, 0x0300}, // Ỳ
+	0x1EF3: {0x0079, 0x0300}, // ỳ
+	0x1EF4: {0x0059, 0x0323}, // Ỵ
+	0x1EF5: {0x0079, 0x0323}, // ỵ
+	0x1EF6: {0x0059, 0x0309}, // Ỷ
+	0x1EF7: {0x0079, 0x0309}, // ỷ
+	0x1EF8: {0x0059, 0x0303}, // Ỹ
+	0x1EF9: {0x0079, 0x0303}, // ỹ
+	0x0374: {0x02B9},         // ʹ
+	0x037E: {0x003B},         // ;
+	0x0385: {0x00A8, 0x0301}, // ΅
+	0x0386: {0x0391, 0x0301}, // Ά
+	0x0387: {0x00B7},         // ·
+	0x0388: {0x0395, 0x0301}, // Έ
+	0x0389: {0x0397, 0x0301}, // Ή
+	0x038A: {0x0399, 0x0301}, // Ί
+	0x038C: {0x039F, 0x0301}, // Ό
+	0x038E: {0x03A5, 0x0301}, // Ύ
+	0x038F: {0x03A9, 0x0301}, // Ώ
+	0x0390: {0x03CA, 0x0301}, // ΐ
+	0x03AA: {0x0399, 0x0308}, // Ϊ
+	0x03AB: {0x03A5, 0x0308}, // Ϋ
+	0x03AC: {0x03B1, 0x0301}, // ά
+	0x03AD: {0x03B5, 0x0301}, // έ
+	0x03AE: {0x03B7, 0x0301}, // ή
+	0x03AF: {0x03B9, 0x0301}, // ί
+	0x03B0: {0x03CB, 0x0301}, // ΰ
+	0x03CA: {0x03B9, 0x0308}, // ϊ
+	0x03CB: {0x03C5, 0x0308}, // ϋ
+	0x03CC: {0x03BF, 0x0301}, // ό
+	0x03CD: {0x03C5, 0x0301}, // ύ
+	0x03CE: {0x03C9, 0x0301}, // ώ
+	0x03D3: {0x03D2, 0x0301}, // ϓ
+	0x03D4: {0x03D2, 0x0308}, // ϔ
+	0x1F00: {0x03B1, 0x0313}, // ἀ
+	0x1F01: {0x03B1, 0x0314}, // ἁ
+	0x1F02: {0x1F00, 0x0300}, // ἂ
+	0x1F03: {0x1F01, 0x0300}, // ἃ
+	0x1F04: {0x1F00, 0x0301}, // ἄ
+	0x1F05: {0x1F01, 0x0301}, // ἅ
+	0x1F06: {0x1F00, 0x0342}, // ἆ
+	0x1F07: {0x1F01, 0x0342}, // ἇ
+	0x1F08: {0x0391, 0x0313}, // Ἀ
+	0x1F09: {0x0391, 0x0314}, // Ἁ
+	0x1F0A: {0x1F08, 0x0300}, // Ἂ
+	0x1F0B: {0x1F09, 0x0300}, // Ἃ
+	0x1F0C: {0x1F08, 0x0301}, // Ἄ
+	0x1F0D: {0x1F09, 0x0301}, // Ἅ
+	0x1F0E: {0x1F08, 0x0342}, // Ἆ
+	0x1F0F: {0x1F09, 0x0342}, // Ἇ
+	0x1F10: {0x03B5, 0x0313}, // ἐ
+	0x1F11: {0x03B5, 0x0314}, // ἑ
+	0x1F12: {0x1F10, 0x0300}, // ἒ
+	0x1F13: {0x1F11, 0x0300}, // ἓ
+	0x1F14: {0x1F10, 0x0301}, // ἔ
+	0x1F15: {0x1F11, 0x0301}, // ἕ
+	0x1F18: {0x0395, 0x0313}, // Ἐ
+	0x1F19: {0x0395, 0x0314}, // Ἑ
+	0x1F1A: {0x1F18, 0x0300}, // Ἒ
+	0x1F1B: {0x1F19, 0x0300}, // Ἓ
+	0x1F1C: {0x1F18, 0x0301}, // Ἔ
+	0x1F1D: {0x1F19, 0x0301}, // Ἕ
+	0x1F20: {0x03B7, 0x0313}, // ἠ
+	0x1F21: {0x03B7, 0x0314}, // ἡ
+	0x1F22: {0x1F20, 0x0300}, // ἢ
+	0x1F23: {0x1F21, 0x0300}, // ἣ
+	0x1F24: {0x1F20, 0x0301}, // ἤ
+	0x1F25: {0x1F21, 0x0301}, // ἥ
+	0x1F26: {0x1F20, 0x0342}, // ἦ
+	0x1F27: {0x1F21, 0x0342}, // ἧ
+	0x1F28: {0x0397, 0x0313}, // Ἠ
+	0x1F29: {0x0397, 0x0314}, // Ἡ
+	0x1F2A: {0x1F28, 0x0300}, // Ἢ
+	0x1F2B: {0x1F29, 0x0300}, // Ἣ
+	0x1F2C: {0x1F28, 0x0301}, // Ἤ
+	0x1F2D: {0x1F29, 0x0301}, // Ἥ
+	0x1F2E: {0x1F28, 0x0342}, // Ἦ
+	0x1F2F: {0x1F29, 0x0342}, // Ἧ
+	0x1F30: {0x03B9, 0x0313}, // ἰ
+	0x1F31: {0x03B9, 0x0314}, // ἱ
+	0x1F32: {0x1F30, 0x0300}, // ἲ
+	0x1F33: {0x1F31, 0x0300}, // ἳ
+	0x1F34: {0x1F30, 0x0301}, // ἴ
+	0x1F35: {0x1F31, 0x0301}, // ἵ
+	0x1F36: {0x1F30, 0x0342}, // ἶ
+	0x1F37: {0x1F31, 0x0342}, // ἷ
+	0x1F38: {0x0399, 0x0313}, // Ἰ
+	0x1F39: {0x0399, 0x0314}, // Ἱ
+	0x1F3A: {0x1F38, 0x0300}, // Ἲ
+	0x1F3B: {0x1F39, 0x0300}, // Ἳ
+	0x1F3C: {0x1F38, 0x0301}, // Ἴ
+	0x1F3D: {0x1F39, 0x0301}, // Ἵ
+	0x1F3E: {0x1F38, 0x0342}, // Ἶ
+	0x1F3F: {0x1F39, 0x0342}, // Ἷ
+	0x1F40: {0x03BF, 0x0313}, // ὀ
+	0x1F41: {0x03BF, 0x0314}, // ὁ
+	0x1F42: {0x1F40, 0x0300}, // ὂ
+	0x1F43: {0x1F41, 0x0300}, // ὃ
+	0x1F44: {0x1F40, 0x0301}, // ὄ
+	0x1F45: {0x1F41, 0x0301}, // ὅ
+	0x1F48: {0x039F, 0x0313}, // Ὀ
+	0x1F49: {0x039F, 0x0314}, // Ὁ
+	0x1F4A: {0x1F48, 0x0300}, // Ὂ
+	0x1F4B: {0x1F49, 0x0300}, // Ὃ
+	0x1F4C: {0x1F48, 0x0301}, // Ὄ
+	0x1F4D: {0x1F49, 0x0301}, // Ὅ
+	0x1F50: {0x03C5, 0x0313}, // ὐ
+	0x1F51: {0x03C5, 0x0314}, // ὑ
+	0x1F52: {0x1F50, 0x0300}, // ὒ
+	0x1F53: {0x1F51, 0x0300}, // ὓ
+	0x1F54: {0x1F50, 0x0301}, // ὔ
+	0x1F55: {0x1F51, 0x0301}, // ὕ
+	0x1F56: {0x1F50, 0x0342}, // ὖ
+	0x1F57: {0x1F51, 0x0342}, // ὗ
+	0x1F59: {0x03A5, 0x0314}, // Ὑ
+	0x1F5B: {0x1F59, 0x0300}, // Ὓ
+	0x1F5D: {0x1F59, 0x0301}, // Ὕ
+	0x1F5F: {0x1F59, 0x0342}, // Ὗ
+	0x1F60: {0x03C9, 0x0313}, // ὠ
+	0x1F61: {0x03C9, 0x0314}, // ὡ
+	0x1F62: {0x1F60, 0x0300}, // ὢ
+	0x1F63: {0x1F61, 0x0300}, // ὣ
+	0x1F64: {0x1F60, 0x0301}, // ὤ
+	0x1F65: {0x1F61, 0x0301}, // ὥ
+	0x1F66: {0x1F60, 0x0342}, // ὦ
+	0x1F67: {0x1F61, 0x0342}, // ὧ
+	0x1F68: {0x03A9, 0x0313}, // Ὠ
+	0x1F69: {0x03A9, 0x0314}, // Ὡ
+	0x1F6A: {0x1F68, 0x0300}, // Ὢ
+	0x1F6B: {0x1F69, 0x0300}, // Ὣ
+	0x1F6C: {0x1F68, 0x0301}, // Ὤ
+	0x1F6D: {0x1F69, 0x0301}, // Ὥ
+	0x1F6E: {0x1F68, 0x0342}, // Ὦ
+	0x1F6F: {0x1F69, 0x0342}, // Ὧ
+	0x1F70: {0x03B1, 0x0300}, // ὰ
+	0x1F71: {0x03AC},         // ά
+	0x1F72: {0x03B5, 0x0300}, // ὲ
+	0x1F73: {0x03AD},         // έ
+	0x1F74: {0x03B7, 0x0300}, // ὴ
+	0x1F75: {0x03AE},         // ή
+	0x1F76: {0x03B9, 0x0300}, // ὶ
+	0x1F77: {0x03AF},         // ί
+	0x1F78: {0x03BF, 0x0300}, // ὸ
+	0x1F79: {0x03CC},         // ό
+	0x1F7A: {0x03C5, 0x0300}, // ὺ
+	0x1F7B: {0x03CD},         // ύ
+	0x1F7C: {0x03C9, 0x0300}, // ὼ
+	0x1F7D: {0x03CE},         // ώ
+	0x1F80: {0x1F00, 0x0345}, // ᾀ
+	0x1F81: {0x1F01, 0x0345}, // ᾁ
+	0x1F82: {0x1F02, 0x0345}, // ᾂ
+	0x1F83: {0x1F03, 0x0345}, // ᾃ
+	0x1F84: {0x1F04, 0x0345}, // ᾄ
+	0x1F85: {0x1F05, 0x0345}, // ᾅ
+	0x1F86: {0x1F06, 0x0345}, // ᾆ
+	0x1F87: {0x1F07, 0x0345}, // ᾇ
+	0x1F88: {0x1F08, 0x0345}, // ᾈ
+	0x1F89: {0x1F09, 0x0345}, // ᾉ
+	0x1F8A: {0x1F0A, 0x0345}, // ᾊ
+	0x1F8B: {0x1F0B, 0x0345}, // ᾋ
+	0x1F8C: {0x1F0C, 0x0345}, // ᾌ
+	0x1F8D: {0x1F0D, 0x0345}, // ᾍ
+	0x1F8E: {0x1F0E, 0x0345}, // ᾎ
+	0x1F8F: {0x1F0F, 0x0345}, // ᾏ
+	0x1F90: {0x1F20, 0x0345}, // ᾐ
+	0x1F91: {0x1F21, 0x0345}, // ᾑ
+	0x1F92: {0x1F22, 0x0345}, // ᾒ
+	0x1F93: {0x1F23, 0x0345}, // ᾓ
+	0x1F94: {0x1F24, 0x0345}, // ᾔ
+	0x1F95: {0x1F25, 0x0345}, // ᾕ
+	0x1F96: {0x1F26, 0x0345}, // ᾖ
+	0x1F97: {0x1F27, 0x0345}, // ᾗ
+	0x1F98: {0x1F28, 0x0345}, // ᾘ
+	0x1F99: {0x1F29, 0x0345}, // ᾙ
+	0x1F9A: {0x1F2A, 0x0345}, // ᾚ
+	0x1F9B: {0x1F2B, 0x0345}, // ᾛ
+	0x1F9C: {0x1F2C, 0x0345}, // ᾜ
+	0x1F9D: {0x1F2D, 0x0345}, // ᾝ
+	0x1F9E: {0x1F2E, 0x0345}, // ᾞ
+	0x1F9F: {0x1F2F, 0x0345}, // ᾟ
+	0x1FA0: {0x1F60, 0x0345}, // ᾠ
+	0x1FA1: {0x1F61, 0x0345}, // ᾡ
+	0x1FA2: {0x1F62, 0x0345}, // ᾢ
+	0x1FA3: {0x1F63, 0x0345}, // ᾣ
+	0x1FA4: {0x1F64, 0x0345}, // ᾤ
+	0x1FA5: {0x1F65, 0x0345}, // ᾥ
+	0x1FA6: {0x1F66, 0x0345}, // ᾦ
+	0x1FA7: {0x1F67, 0x0345}, // ᾧ
+	0x1FA8: {0x1F68, 0x0345}, // ᾨ
+	0x1FA9: {0x1F69, 0x0345}, // ᾩ
+	0x1FAA: {0x1F6A, 0x0345}, // ᾪ
+	0x1FAB: {0x1F6B, 0x0345}, // ᾫ
+	0x1FAC: {0x1F6C, 0x0345}, // ᾬ
+	0x1FAD: {0x1F6D, 0x0345}, // ᾭ
+	0x1FAE: {0x1F6E, 0x0345}, // ᾮ
+	0x1FAF: {0x1F6F, 0x0345}, // ᾯ
+	0x1FB0: {0x03B1, 0x0306}, // ᾰ
+	0x1FB1: {0x03B1, 0x0304}, // ᾱ
+	0x1FB2: {0x1F70, 0x0345}, // ᾲ
+	0x1FB3: {0x03B1, 0x0345}, // ᾳ
+	0x1FB4: {0x03AC, 0x0345}, // ᾴ
+	0x1FB6: {0x03B1, 0x0342}, // ᾶ
+	0x1FB7: {0x1FB6, 0x0345}, // ᾷ
+	0x1FB8: {0x0391, 0x0306}, // Ᾰ
+	0x1FB9: {0x0391, 0x0304}, // Ᾱ
+	0x1FBA: {0x0391, 0x0300}, // Ὰ
+	0x1FBB: {0x0386},         // Ά
+	0x1FBC: {0x0391, 0x0345}, // ᾼ
+	0x1FBE: {0x03B9},         // ι
+	0x1FC1: {0x00A8, 0x0342}, // ῁
+	0x1FC2: {0x1F74, 0x0345}, // ῂ
+	0x1FC3: {0x03B7, 0x0345}, // ῃ
+	0x1FC4: {0x03AE, 0x0345}, // ῄ
+	0x1FC6: {0x03B7, 0x0342}, // ῆ
+	0x1FC7: {0x1FC6, 0x0345}, // ῇ
+	0x1FC8: {0x0395, 0x0300}, // Ὲ
+	0x1FC9: {0x0388},         // Έ
+	0x1FCA: {0x0397, 0x0300}, // Ὴ
+	0x1FCB: {0x0389},         // Ή
+	0x1FCC: {0x0397, 0x0345}, // ῌ
+	0x1FCD: {0x1FBF, 0x0300}, // ῍
+	0x1FCE: {0x1FBF, 0x0301}, // ῎
+	0x1FCF: {0x1FBF, 0x0342}, // ῏
+	0x1FD0: {0x03B9, 0x0306}, // ῐ
+	0x1FD1: {0x03B9, 0x0304}, // ῑ
+	0x1FD2: {0x03CA, 0x0300}, // ῒ
+	0x1FD3: {0x0390},         // ΐ
+	0x1FD6: {0x03B9, 0x0342}, // ῖ
+	0x1FD7: {0x03CA, 0x0342}, // ῗ
+	0x1FD8: {0x0399, 0x0306}, // Ῐ
+	0x1FD9: {0x0399, 0x0304}, // Ῑ
+	0x1FDA: {0x0399, 0x0300}, // Ὶ
+	0x1FDB: {0x038A},         // Ί
+	0x1FDD: {0x1FFE, 0x0300}, // ῝
+	0x1FDE: {0x1FFE, 0x0301}, // ῞
+	0x1FDF: {0x1FFE, 0x0342}, // ῟
+	0x1FE0: {0x03C5, 0x0306}, // ῠ
+	0x1FE1: {0x03C5, 0x0304}, // ῡ
+	0x1FE2: {0x03CB, 0x0300}, // ῢ
+	0x1FE3: {0x03B0},         // ΰ
+	0x1FE4: {0x03C1, 0x0313}, // ῤ
+	0x1FE5: {0x03C1, 0x0314}, // ῥ
+	0x1FE6: {0x03C5, 0x0342}, // ῦ
+	0x1FE7: {0x03CB, 0x0342}, // ῧ
+	0x1FE8: {0x03A5, 0x0306}, // Ῠ
+	0x1FE9: {0x03A5, 0x0304}, // Ῡ
+	0x1FEA: {0x03A5, 0x0300}, // Ὺ
+	0x1FEB: {0x038E},         // Ύ
+	0x1FEC: {0x03A1, 0x0314}, // Ῥ
+	0x1FED: {0x00A8, 0x0300}, // ῭
+	0x1FEE: {0x0385},         // ΅
+	0x1FEF: {0x0060},         // `
+	0x1FF2: {0x1F7C, 0x0345}, // ῲ
+	0x1FF3: {0x03C9, 0x0345}, // ῳ
+	0x1FF4: {0x03CE, 0x0345}, // ῴ
+	0x1FF6: {0x03C9, 0x0342}, // ῶ
+	0x1FF7: {0x1FF6, 0x0345}, // ῷ
+	0x1FF8: {0x039F, 0x0300}, // Ὸ
+	0x1FF9: {0x038C},         // Ό
+	0x1FFA: {0x03A9, 0x0300}, // Ὼ
+	0x1FFB: {0x038F},         // Ώ
+	0x1FFC: {0x03A9, 0x0345}, // ῼ
+	0x1FFD: {0x00B4},         // ´
+	0x0400: {0x0415, 0x0300}, // Ѐ
+	0x0401: {0x0415, 0x0308}, // Ё
+	0x0403: {0x0413, 0x0301}, // Ѓ
+	0x0407: {0x0406, 0x0308}, // Ї
+	0x040C: {0x041A, 0x0301}, // Ќ
+	0x040D: {0x0418, 0x0300}, // Ѝ
+	0x040E: {0x0423, 0x0306}, // Ў
+	0x0419: {0x0418, 0x0306}, // Й
+	0x0439: {0x0438, 0x0306}, // й
+	0x0450: {0x0435, 0x0300}, // ѐ
+	0x0451: {0x0435, 0x0308}, // ё
+	0x0453: {0x0433, 0x0301}, // ѓ
+	0x0457: {0x0456, 0x0308}, // ї
+	0x045C: {0x043A, 0x0301}, // ќ
+	0x045D: {0x0438, 0x0300}, // ѝ
+	0x045E: {0x0443, 0x0306}, // ў
+	0x0476: {0x0474, 0x030F}, // Ѷ
+	0x0477: {0x0475, 0x030F}, // ѷ
+	0x04C1: {0x0416, 0x0306}, // Ӂ
+	0x04C2: {0x0436, 0x0306}, // ӂ
+	0x04D0: {0x0410, 0x0306}, // Ӑ
+	0x04D1: {0x0430, 0x0306}, // ӑ
+	0x04D2: {0x0410, 0x0308}, // Ӓ
+	0x04D3: {0x0430, 0x0308}, // ӓ
+	0x04D6: {0x0415, 0x0306}, // Ӗ
+	0x04D7: {0x0435, 0x0306}, // ӗ
+	0x04DA: {0x04D8, 0x0308}, // Ӛ
+	0x04DB: {0x04D9, 0x0308}, // ӛ
+	0x04DC: {0x0416, 0x0308}, // Ӝ
+	0x04DD: {0x0436, 0x0308}, // ӝ
+	0x04DE: {0x0417, 0x0308}, // Ӟ
+	0x04DF: {0x0437, 0x0308}, // ӟ
+	0x04E2: {0x0418, 0x0304}, // Ӣ
+	0x04E3: {0x0438, 0x0304}, // ӣ
+	0x04E4: {0x0418, 0x0308}, // Ӥ
+	0x04E5: {0x0438, 0x0308}, // ӥ
+	0x04E6: {0x041E, 0x0308}, // Ӧ
+	0x04E7: {0x043E, 0x0308}, // ӧ
+	0x04EA: {0x04E8, 0x0308}, // Ӫ
+	0x04EB: {0x04E9, 0x0308}, // ӫ
+	0x04EC: {0x042D, 0x0308}, // Ӭ
+	0x04ED: {0x044D, 0x0308}, // ӭ
+	0x04EE: {0x0423, 0x0304}, // Ӯ
+	0x04EF: {0x0443, 0x0304}, // ӯ
+	0x04F0: {0x0423, 0x0308}, // Ӱ
+	0x04F1: {0x0443, 0x0308}, // ӱ
+	0x04F2: {0x0423, 0x030B}, // Ӳ
+	0x04F3: {0x0443, 0x030B}, // ӳ
+	0x04F4: {0x0427, 0x0308}, // Ӵ
+	0x04F5: {0x0447, 0x0308}, // ӵ
+	0x04F8: {0x042B, 0x0308}, // Ӹ
+	0x04F9: {0x044B, 0x0308}, // ӹ
+}
@@ -0,0 +1,24 @@
+// Package palindrome holds the rune-by-rune palindrome check shared by the
+// wordlens implementations.
+package palindrome
+
+import "unicode/utf8"
+
+// Runes reports whether word reads the same in both directions, comparing
+// runes rather than bytes so multi-byte characters such as the é in "été"
+// are not split. Runes are decoded from both ends of word so that, unlike
+// converting to []rune, it does not allocate. Invalid UTF-8 decodes one
+// byte at a time as utf8.RuneError, and the empty word is a palindrome.
+func Runes(word string) bool {
+	i, j := 0, len(word)
+	for i < j {
+		first, n := utf8.DecodeRuneInString(word[i:j])
+		last, m := utf8.DecodeLastRuneInString(word[i:j])
+		if first != last {
+			return false
+		}
+		i += n
+		j -= m
+	}
+	return true
+}
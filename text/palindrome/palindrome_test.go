@@ -0,0 +1,32 @@
+package palindrome_test
+
+import (
+	"testing"
+
+	"github.com/idiomat/goo11ynyt/text/palindrome"
+)
+
+func TestRunes(t *testing.T) {
+	tests := map[string]struct {
+		word     string
+		expected bool
+	}{
+		"empty":            {word: "", expected: true},
+		"single":           {word: "a", expected: true},
+		"ascii":            {word: "level", expected: true},
+		"not a palindrome": {word: "hello", expected: false},
+		"multi-byte":       {word: "\u00e9t\u00e9", expected: true},
+		"mixed widths":     {word: "aéa", expected: true},
+		"invalid utf-8":    {word: "a\xffa", expected: true},
+		"split rune":       {word: "\xc3\xa9\xa9\xc3", expected: false},
+		"combining mark":   {word: "e\u0301te\u0301", expected: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := palindrome.Runes(tc.word); got != tc.expected {
+				t.Errorf("Expected %t for %q, got %t", tc.expected, tc.word, got)
+			}
+		})
+	}
+}
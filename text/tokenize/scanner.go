@@ -0,0 +1,68 @@
+package tokenize
+
+import (
+	"bufio"
+	"io"
+)
+
+// Position locates a word in its source text. Offset is in bytes, Line and
+// Column are 1-based and Column counts runes.
+type Position struct {
+	Offset int `json:"offset"`
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// advance returns the position just past text, which starts at p.
+func (p Position) advance(text []byte) Position {
+	p.Offset += len(text)
+	for len(text) > 0 {
+		c, n := decodeRune(text)
+		if c == '\n' {
+			p.Line++
+			p.Column = 1
+		} else {
+			p.Column++
+		}
+		text = text[n:]
+	}
+	return p
+}
+
+// Scanner reads words of up to MaxWordSize bytes from a reader like a
+// bufio.Scanner using Rules.SplitFunc, and also records where each word
+// starts and ends in the source, which for a rewritten word is not just its
+// start plus its length.
+type Scanner struct {
+	*bufio.Scanner
+	start, end Position
+	next       Position // position of the data handed to the next split
+}
+
+func NewScanner(rdr io.Reader, r Rules) *Scanner {
+	s := &Scanner{
+		Scanner: bufio.NewScanner(rdr),
+		next:    Position{Line: 1, Column: 1},
+	}
+	s.Buffer(nil, MaxWordSize)
+	s.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, start, end, token := r.scan(data, atEOF)
+		if token != nil {
+			s.start = s.next.advance(data[:start])
+			s.end = s.start.advance(data[start:end])
+		}
+		s.next = s.next.advance(data[:advance])
+		return advance, token, nil
+	})
+	return s
+}
+
+// Start returns the position of the first byte of the last word read.
+func (s *Scanner) Start() Position {
+	return s.start
+}
+
+// End returns the position just past the last word read.
+func (s *Scanner) End() Position {
+	return s.end
+}
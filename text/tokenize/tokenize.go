@@ -0,0 +1,318 @@
+// Package tokenize splits text into words. None, the zero Rules, splits
+// around white space exactly like strings.Fields; the other rules strip
+// punctuation, rejoin hyphenated words, handle apostrophes or split at
+// Unicode word boundaries instead.
+package tokenize
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/idiomat/goo11ynyt/text/internal/ruleset"
+)
+
+// Rules is a set of tokenization rules that can be combined with |.
+type Rules uint8
+
+const (
+	// StripPunctuation trims punctuation from both ends of a word, so
+	// "print." and "(print" are both "print". Words that are nothing but
+	// punctuation are dropped.
+	StripPunctuation Rules = 1 << iota
+	// JoinHyphens rejoins a word hyphenated across a line break, such as
+	// "palin-\ndrome", and with WordBoundaries keeps compounds such as
+	// "well-known" in one piece.
+	JoinHyphens
+	// Apostrophes folds ’ into ' so "don’t" and "don't" are the same word,
+	// and with WordBoundaries keeps an apostrophe between two letters as
+	// part of the word.
+	Apostrophes
+	// WordBoundaries splits at word boundaries rather than white space:
+	// words are runs of letters, marks and numbers, and any other character
+	// ends one, except for the cases above and separators within numbers
+	// such as "3.14" or "1,000". It implies StripPunctuation.
+	WordBoundaries
+)
+
+// None splits around white space only, like strings.Fields.
+const None Rules = 0
+
+// All enables every rule, which gives the words a reader would pick out of
+// running text.
+const All = StripPunctuation | JoinHyphens | Apostrophes | WordBoundaries
+
+// Split returns the words of s.
+func (r Rules) Split(s string) []string {
+	if r == None {
+		return strings.Fields(s)
+	}
+
+	words := []string{}
+	r.Fields([]byte(s), func(word []byte) {
+		words = append(words, string(word))
+	})
+	return words
+}
+
+// Fields calls fn with every word of text. Words are sub-slices of text
+// unless a rule had to rewrite them, so None allocates nothing; fn
+// must copy a word it wants to keep.
+func (r Rules) Fields(text []byte, fn func(word []byte)) {
+	if r == None {
+		fields(text, fn)
+		return
+	}
+
+	for len(text) > 0 {
+		advance, _, _, token := r.scan(text, true)
+		if token != nil {
+			fn(token)
+		}
+		text = text[advance:]
+	}
+}
+
+// MaxWordSize is the longest word a Scanner reads, far beyond the 64 KiB
+// a bufio.Scanner allows by default. Scanners given SplitFunc should be
+// given a buffer of this size too.
+const MaxWordSize = 16 << 20
+
+// SplitFunc returns a bufio.SplitFunc that reads words with r. With None it
+// behaves like bufio.ScanWords.
+func (r Rules) SplitFunc() bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, _, _, token := r.scan(data, atEOF)
+		return advance, token, nil
+	}
+}
+
+var asciiSpace = [utf8.RuneSelf]bool{'\t': true, '\n': true, '\v': true, '\f': true, '\r': true, ' ': true}
+
+// fields is Fields for None, kept apart because it is on the hot
+// path of the optimized wordlens.
+func fields(text []byte, fn func(word []byte)) {
+	start := -1
+	for i := 0; i < len(text); {
+		c, size := rune(text[i]), 1
+		var space bool
+		if c < utf8.RuneSelf {
+			space = asciiSpace[c]
+		} else {
+			c, size = utf8.DecodeRune(text[i:])
+			space = unicode.IsSpace(c)
+		}
+
+		if space {
+			if start >= 0 {
+				fn(text[start:i])
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+		i += size
+	}
+	if start >= 0 {
+		fn(text[start:])
+	}
+}
+
+// scan finds the next word in data. It returns how much of data it
+// consumed, where the word starts and ends in data and the word itself,
+// which is nil if data holds no complete word. Unless atEOF, a word running
+// up to the end of data is left for a later call with more data.
+func (r Rules) scan(data []byte, atEOF bool) (advance, start, end int, token []byte) {
+	i := 0
+	for i < len(data) {
+		c, n := decodeRune(data[i:])
+		if !r.separates(c) && (r&StripPunctuation == 0 || !unicode.IsPunct(c)) {
+			break
+		}
+		i += n
+	}
+	start = i
+	if start == len(data) {
+		return start, start, start, nil
+	}
+
+	edited := false
+	prev := rune(-1)
+	for i < len(data) {
+		c, n := decodeRune(data[i:])
+
+		if r&JoinHyphens != 0 && isHyphen(c) && unicode.IsLetter(prev) {
+			size, ok, more := lineBreak(data[i+n:], atEOF)
+			if more {
+				return start, start, start, nil
+			}
+			if ok {
+				edited = true
+				i += n + size
+				prev = c
+				continue
+			}
+		}
+
+		if r.separates(c) {
+			if !joins(c) {
+				break
+			}
+			next, more := peekRune(data[i+n:], atEOF)
+			if more {
+				return start, start, start, nil
+			}
+			if !r.inside(prev, c, next) {
+				break
+			}
+		}
+
+		edited = edited || c == '’' && r&Apostrophes != 0
+		i += n
+		prev = c
+	}
+	if i == len(data) && !atEOF {
+		return start, start, start, nil
+	}
+
+	advance = i
+	if r&StripPunctuation != 0 {
+		for i > start {
+			c, n := utf8.DecodeLastRune(data[start:i])
+			if !unicode.IsPunct(c) {
+				break
+			}
+			i -= n
+		}
+	}
+	token = data[start:i]
+	if edited {
+		token = r.rewrite(token)
+	}
+	return advance, start, i, token
+}
+
+// rewrite drops the hyphens and line breaks that split word across lines
+// and folds its apostrophes, for a word that scan found needs it.
+func (r Rules) rewrite(word []byte) []byte {
+	buf := make([]byte, 0, len(word))
+	prev := rune(-1)
+	for i := 0; i < len(word); {
+		c, n := decodeRune(word[i:])
+		switch {
+		case r&JoinHyphens != 0 && isHyphen(c) && unicode.IsLetter(prev):
+			if size, ok, _ := lineBreak(word[i+n:], true); ok {
+				i += n + size
+				prev = c
+				continue
+			}
+			buf = append(buf, word[i:i+n]...)
+		case c == '’' && r&Apostrophes != 0:
+			buf = append(buf, '\'')
+		default:
+			buf = append(buf, word[i:i+n]...)
+		}
+		i += n
+		prev = c
+	}
+	return buf
+}
+
+// separates reports whether c ends a word.
+func (r Rules) separates(c rune) bool {
+	if r&WordBoundaries != 0 {
+		return !isWord(c)
+	}
+	return unicode.IsSpace(c)
+}
+
+// inside reports whether the separator c, found between prev and next,
+// belongs to the word rather than ending it.
+func (r Rules) inside(prev, c, next rune) bool {
+	switch {
+	case c == '\'' || c == '’':
+		return r&Apostrophes != 0 && unicode.IsLetter(prev) && unicode.IsLetter(next)
+	case isHyphen(c):
+		return r&JoinHyphens != 0 && isWord(prev) && isWord(next)
+	case c == '.' || c == ',':
+		return unicode.IsDigit(prev) && unicode.IsDigit(next)
+	}
+	return false
+}
+
+// joins reports whether c is a separator that inside may keep in a word.
+func joins(c rune) bool {
+	return c == '\'' || c == '’' || isHyphen(c) || c == '.' || c == ','
+}
+
+// lineBreak measures the white space after a hyphen and reports whether it
+// breaks the line in the middle of a word, i.e. holds a newline and is
+// followed by a lowercase letter.
+func lineBreak(data []byte, atEOF bool) (size int, ok, more bool) {
+	newline := false
+	for size < len(data) {
+		c, n := decodeRune(data[size:])
+		if !unicode.IsSpace(c) {
+			break
+		}
+		newline = newline || c == '\n'
+		size += n
+	}
+	next, more := peekRune(data[size:], atEOF)
+	return size, newline && unicode.IsLower(next), more
+}
+
+// peekRune returns the first rune of data, or -1 if there is none. more is
+// set if data ends before a whole rune and more data could follow.
+func peekRune(data []byte, atEOF bool) (c rune, more bool) {
+	if !utf8.FullRune(data) {
+		if !atEOF {
+			return -1, true
+		}
+		if len(data) == 0 {
+			return -1, false
+		}
+	}
+	c, _ = decodeRune(data)
+	return c, false
+}
+
+func decodeRune(data []byte) (rune, int) {
+	if data[0] < utf8.RuneSelf {
+		return rune(data[0]), 1
+	}
+	return utf8.DecodeRune(data)
+}
+
+// isWord reports whether c is part of a word. Invalid bytes are kept in
+// words rather than dropped.
+func isWord(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsNumber(c) || unicode.IsMark(c) || c == utf8.RuneError
+}
+
+func isHyphen(c rune) bool {
+	return c == '-' || c == '‐'
+}
+
+var ruleNames = []ruleset.Name[Rules]{
+	{Rule: StripPunctuation, Name: "punct"},
+	{Rule: JoinHyphens, Name: "hyphens"},
+	{Rule: Apostrophes, Name: "apostrophes"},
+	{Rule: WordBoundaries, Name: "words"},
+}
+
+func (r Rules) String() string {
+	return ruleset.Format(r, ruleNames)
+}
+
+// ParseRules is the inverse of Rules.String: it reads a comma separated list
+// of rule names, or "all" or "none".
+func ParseRules(s string) (Rules, error) {
+	r, err := ruleset.Parse(s, All, ruleNames)
+	if err != nil {
+		return 0, fmt.Errorf("tokenize: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,210 @@
+package tokenize_test
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/idiomat/goo11ynyt/text/tokenize"
+)
+
+var splitTests = map[string]struct {
+	rules    tokenize.Rules
+	input    string
+	expected []string
+}{
+	"empty": {
+		rules:    tokenize.All,
+		input:    "",
+		expected: []string{},
+	},
+	"none keeps punctuation": {
+		rules:    tokenize.None,
+		input:    "print. (print) well-known don’t",
+		expected: []string{"print.", "(print)", "well-known", "don’t"},
+	},
+	"strip punctuation": {
+		rules:    tokenize.StripPunctuation,
+		input:    "print. (print) -- \"Madam,\" well-known",
+		expected: []string{"print", "print", "Madam", "well-known"},
+	},
+	"join hyphens across lines": {
+		rules:    tokenize.JoinHyphens,
+		input:    "a palin-\n  drome, not a Stoic-\nRoman or a -\nb",
+		expected: []string{"a", "palindrome,", "not", "a", "Stoic-", "Roman", "or", "a", "-", "b"},
+	},
+	"join hyphens across crlf": {
+		rules:    tokenize.JoinHyphens | tokenize.StripPunctuation,
+		input:    "palin-\r\ndrome.",
+		expected: []string{"palindrome"},
+	},
+	"fold apostrophes": {
+		rules:    tokenize.Apostrophes,
+		input:    "don’t don't",
+		expected: []string{"don't", "don't"},
+	},
+	"word boundaries": {
+		rules:    tokenize.WordBoundaries,
+		input:    "\"Madam, I'm Adam\"—well-known; 3.14, 1,000 and été!",
+		expected: []string{"Madam", "I", "m", "Adam", "well", "known", "3.14", "1,000", "and", "été"},
+	},
+	"all": {
+		rules:    tokenize.All,
+		input:    "\"Madam, I’m Adam\"—well-known; 'tis the pa-\nlindrome's end.",
+		expected: []string{"Madam", "I'm", "Adam", "well-known", "tis", "the", "palindrome's", "end"},
+	},
+	"unicode": {
+		rules:    tokenize.All,
+		input:    "Ἀθῆναι, été… 日本語。",
+		expected: []string{"Ἀθῆναι", "été", "日本語"},
+	},
+	"invalid bytes": {
+		rules:    tokenize.WordBoundaries,
+		input:    "ab\xffba, cd",
+		expected: []string{"ab\xffba", "cd"},
+	},
+}
+
+func TestRulesSplit(t *testing.T) {
+	for name, tc := range splitTests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.rules.Split(tc.input); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRulesSplitFunc(t *testing.T) {
+	for name, tc := range splitTests {
+		t.Run(name, func(t *testing.T) {
+			// one byte at a time makes every word straddle a read
+			scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(tc.input)))
+			scanner.Split(tc.rules.SplitFunc())
+			got := []string{}
+			for scanner.Scan() {
+				got = append(got, scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNoRulesMatchStringsFields(t *testing.T) {
+	inputs := []string{
+		"",
+		"   ",
+		" a  b\tc\n",
+		"print. (print) well-known don’t",
+		"été été 🇫🇷x🇫🇷 \xffab\xc3",
+		"\u0085x y　z",
+	}
+
+	for _, input := range inputs {
+		expected := strings.Fields(input)
+
+		var got []string
+		tokenize.None.Fields([]byte(input), func(word []byte) {
+			got = append(got, string(word))
+		})
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Errorf("Expected Fields(%q) to be %q, got %q", input, expected, got)
+		}
+
+		scanner := bufio.NewScanner(strings.NewReader(input))
+		scanner.Split(tokenize.None.SplitFunc())
+		got = got[:0]
+		for scanner.Scan() {
+			got = append(got, scanner.Text())
+		}
+		if len(got) != len(expected) || (len(got) > 0 && !reflect.DeepEqual(got, expected)) {
+			t.Errorf("Expected SplitFunc(%q) to be %q, got %q", input, expected, got)
+		}
+	}
+}
+
+func TestScanner(t *testing.T) {
+	type word struct {
+		text       string
+		start, end tokenize.Position
+	}
+
+	input := "Step on\nno pe-\n  ts! été"
+	expected := []word{
+		{"Step", tokenize.Position{Offset: 0, Line: 1, Column: 1}, tokenize.Position{Offset: 4, Line: 1, Column: 5}},
+		{"on", tokenize.Position{Offset: 5, Line: 1, Column: 6}, tokenize.Position{Offset: 7, Line: 1, Column: 8}},
+		{"no", tokenize.Position{Offset: 8, Line: 2, Column: 1}, tokenize.Position{Offset: 10, Line: 2, Column: 3}},
+		{"pets", tokenize.Position{Offset: 11, Line: 2, Column: 4}, tokenize.Position{Offset: 19, Line: 3, Column: 5}},
+		{"été", tokenize.Position{Offset: 21, Line: 3, Column: 7}, tokenize.Position{Offset: 26, Line: 3, Column: 10}},
+	}
+
+	scanner := tokenize.NewScanner(iotest.OneByteReader(strings.NewReader(input)), tokenize.All)
+	var got []word
+	for scanner.Scan() {
+		got = append(got, word{scanner.Text(), scanner.Start(), scanner.End()})
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestRulesString(t *testing.T) {
+	if got := tokenize.All.String(); got != "punct,hyphens,apostrophes,words" {
+		t.Errorf("Expected %q, got %q", "punct,hyphens,apostrophes,words", got)
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		expected  tokenize.Rules
+		expectErr bool
+	}{
+		"empty": {
+			input:    "",
+			expected: 0,
+		},
+		"none": {
+			input:    "none",
+			expected: tokenize.None,
+		},
+		"all": {
+			input:    "all",
+			expected: tokenize.All,
+		},
+		"list": {
+			input:    "punct, hyphens",
+			expected: tokenize.StripPunctuation | tokenize.JoinHyphens,
+		},
+		"round trip": {
+			input:    tokenize.All.String(),
+			expected: tokenize.All,
+		},
+		"unknown": {
+			input:     "punct,sentences",
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tokenize.ParseRules(tc.input)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if got != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}